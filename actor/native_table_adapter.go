@@ -148,6 +148,5 @@ func (nta *NativeTableAdapter) Raise(playerID string, chipLevel int64) error {
 }
 
 func (nta *NativeTableAdapter) ExtendTime(playerID string, duration time.Duration) error {
-	//TODO: need to be implemented
-	return nil
+	return nta.table.ExtendTime(playerID, duration)
 }
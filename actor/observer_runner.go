@@ -1,6 +1,7 @@
 package actor
 
 import (
+	"github.com/weedbox/pokerface"
 	"github.com/weedbox/pokertable"
 )
 
@@ -8,11 +9,13 @@ type ObserverRunner struct {
 	actor               Actor
 	tableInfo           *pokertable.Table
 	systemMode          bool
+	redaction           *pokerface.RedactionPolicy
 	onTableStateUpdated func(*pokertable.Table)
 }
 
 func NewObserverRunner() *ObserverRunner {
 	return &ObserverRunner{
+		redaction:           pokerface.NewRedactionPolicy(),
 		onTableStateUpdated: func(*pokertable.Table) {},
 	}
 }
@@ -25,9 +28,11 @@ func (obr *ObserverRunner) EnabledSystemMode(enabled bool) {
 	obr.systemMode = enabled
 }
 
-func (obr *ObserverRunner) UpdateTableState(tableInfo *pokertable.Table) error {
+func (obr *ObserverRunner) SetRedactionPolicy(policy *pokerface.RedactionPolicy) {
+	obr.redaction = policy
+}
 
-	obr.tableInfo = tableInfo
+func (obr *ObserverRunner) UpdateTableState(tableInfo *pokertable.Table) error {
 
 	if !obr.systemMode {
 		// Filtering private information for observer
@@ -35,10 +40,12 @@ func (obr *ObserverRunner) UpdateTableState(tableInfo *pokertable.Table) error {
 		case pokertable.TableStateStatus_TableGamePlaying:
 			fallthrough
 		case pokertable.TableStateStatus_TableGameSettled:
-			tableInfo.State.GameState.AsObserver()
+			tableInfo = redactTable(tableInfo, pokerface.ViewerObserver, obr.redaction)
 		}
 	}
 
+	obr.tableInfo = tableInfo
+
 	// Emit event
 	obr.onTableStateUpdated(tableInfo)
 
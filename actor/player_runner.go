@@ -24,6 +24,7 @@ type PlayerRunner struct {
 	lastGameStateTime   int64
 	tableInfo           *pokertable.Table
 	timebank            *timebank.TimeBank
+	redaction           *pokerface.RedactionPolicy
 	onTableStateUpdated func(*pokertable.Table)
 
 	// status
@@ -38,6 +39,7 @@ func NewPlayerRunner(playerID string) *PlayerRunner {
 		timebank:            timebank.NewTimeBank(),
 		status:              PlayerStatus_Running,
 		suspendThreshold:    2,
+		redaction:           pokerface.NewRedactionPolicy(),
 		onTableStateUpdated: func(*pokertable.Table) {},
 	}
 }
@@ -47,6 +49,10 @@ func (pr *PlayerRunner) SetActor(a Actor) {
 	pr.actions = NewActions(a, pr.playerID)
 }
 
+func (pr *PlayerRunner) SetRedactionPolicy(policy *pokerface.RedactionPolicy) {
+	pr.redaction = policy
+}
+
 func (pr *PlayerRunner) UpdateTableState(table *pokertable.Table) error {
 
 	gs := table.State.GameState
@@ -97,8 +103,8 @@ func (pr *PlayerRunner) UpdateTableState(table *pokertable.Table) error {
 			return nil
 		}
 
-		// Filtering private information fpr player
-		gs.AsPlayer(gamePlayerIdx)
+		// Filtering private information for player
+		gs = gs.Redact(gamePlayerIdx, pr.redaction)
 
 		// We have actions allowed by game engine
 		player := gs.GetPlayer(gamePlayerIdx)
@@ -168,7 +174,7 @@ func (pr *PlayerRunner) automate(gs *pokerface.GameState, playerIdx int) error {
 		// blinds
 		if gs.HasPosition(playerIdx, "sb") {
 			return pr.actions.Pay(gs.Meta.Blind.SB)
-		} else if gs.HasPosition(playerIdx, "bb") {
+		} else if gs.HasPosition(playerIdx, "bb") || gs.HasPosition(playerIdx, "post") {
 			return pr.actions.Pay(gs.Meta.Blind.BB)
 		}
 
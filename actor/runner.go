@@ -1,6 +1,7 @@
 package actor
 
 import (
+	"github.com/weedbox/pokerface"
 	"github.com/weedbox/pokertable"
 )
 
@@ -8,3 +9,20 @@ type Runner interface {
 	SetActor(a Actor)
 	UpdateTableState(t *pokertable.Table) error
 }
+
+// redactTable returns a copy of table with redacted game state, the original
+// one is shared with other runners.
+func redactTable(tableInfo *pokertable.Table, viewer int, policy *pokerface.RedactionPolicy) *pokertable.Table {
+
+	if tableInfo.State == nil || tableInfo.State.GameState == nil {
+		return tableInfo
+	}
+
+	state := *tableInfo.State
+	state.GameState = state.GameState.Redact(viewer, policy)
+
+	t := *tableInfo
+	t.State = &state
+
+	return &t
+}
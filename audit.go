@@ -0,0 +1,359 @@
+package pokerface
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/weedbox/pokerface/card"
+)
+
+// Rules of audit
+const (
+	AuditRuleChips          = "chips"           // stack, wager and pot of player are conserved
+	AuditRuleRoundPot       = "round_pot"       // pot of current round matches wagers
+	AuditRulePots           = "pots"            // pot totals match contributions
+	AuditRuleCards          = "cards"           // cards are valid and not duplicated
+	AuditRuleAllowedActions = "allowed_actions" // only current player is able to act
+	AuditRuleSettlement     = "settlement"      // settlement sums to zero
+)
+
+var bettingActions = []string{"fold", "call", "check", "bet", "raise", "allin"}
+
+// Violation is an invariant which is broken by game state
+type Violation struct {
+	Rule    string `json:"rule"`
+	Player  int    `json:"player"` // -1 if not related to any player
+	Message string `json:"message"`
+}
+
+func (v Violation) String() string {
+
+	if v.Player < 0 {
+		return fmt.Sprintf("%s: %s", v.Rule, v.Message)
+	}
+
+	return fmt.Sprintf("%s: player %d: %s", v.Rule, v.Player, v.Message)
+}
+
+// AuditError is raised in debug builds if game state breaks invariants
+type AuditError struct {
+	Event      string
+	Violations []Violation
+}
+
+func (e *AuditError) Error() string {
+
+	msgs := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		msgs[i] = v.String()
+	}
+
+	return fmt.Sprintf("audit: %s: %s", e.Event, strings.Join(msgs, "; "))
+}
+
+// AuditHandler receives violations which were found after an event
+type AuditHandler func(gs *GameState, violations []Violation)
+
+// Audit checks invariants of game state. Only cards in state are checked,
+// Game.Audit checks cards in secrets as well.
+func Audit(gs *GameState) []Violation {
+	return audit(gs, nil)
+}
+
+// Audit checks invariants of game state with secrets
+func (g *game) Audit() []Violation {
+
+	// Deck in secrets is not used if cards come from other dealing
+	d, _ := g.getDealing()
+	if _, ok := d.(*deckDealing); !ok {
+		return audit(g.gs, nil)
+	}
+
+	return audit(g.gs, g.secrets)
+}
+
+// SetAuditor audits game after every event
+func (g *game) SetAuditor(h AuditHandler) {
+	g.auditor = h
+}
+
+// runAudit is called after every event. Allowed actions are updated by the
+// next event of chain, so they are checked only if state is settled.
+func (g *game) runAudit(settled bool) {
+
+	if g.auditor == nil && !auditAlways {
+		return
+	}
+
+	violations := make([]Violation, 0)
+	for _, v := range g.Audit() {
+		if settled || v.Rule != AuditRuleAllowedActions {
+			violations = append(violations, v)
+		}
+	}
+
+	if len(violations) == 0 {
+		return
+	}
+
+	if g.auditor != nil {
+		g.auditor(g.gs, violations)
+		return
+	}
+
+	panic(&AuditError{
+		Event:      g.gs.Status.CurrentEvent,
+		Violations: violations,
+	})
+}
+
+func audit(gs *GameState, s *Secrets) []Violation {
+
+	violations := make([]Violation, 0)
+	violations = append(violations, auditChips(gs)...)
+	violations = append(violations, auditPots(gs)...)
+	violations = append(violations, auditCards(gs, s)...)
+	violations = append(violations, auditAllowedActions(gs)...)
+	violations = append(violations, auditSettlement(gs)...)
+
+	return violations
+}
+
+func auditChips(gs *GameState) []Violation {
+
+	violations := make([]Violation, 0)
+
+	var wagers int64
+	for _, p := range gs.Players {
+
+		wagers += p.Wager
+
+		if p.Pot < 0 || p.Wager < 0 || p.StackSize < 0 || p.DeadBlind < 0 {
+			violations = append(violations, Violation{
+				Rule:    AuditRuleChips,
+				Player:  p.Idx,
+				Message: fmt.Sprintf("negative chips (pot=%d, wager=%d, stack_size=%d, dead_blind=%d)", p.Pot, p.Wager, p.StackSize, p.DeadBlind),
+			})
+		}
+
+		if p.InitialStackSize != p.Bankroll-p.Pot-p.DeadBlind {
+			violations = append(violations, Violation{
+				Rule:    AuditRuleChips,
+				Player:  p.Idx,
+				Message: fmt.Sprintf("initial_stack_size %d != bankroll %d - pot %d - dead_blind %d", p.InitialStackSize, p.Bankroll, p.Pot, p.DeadBlind),
+			})
+		}
+
+		if p.StackSize != p.InitialStackSize-p.Wager {
+			violations = append(violations, Violation{
+				Rule:    AuditRuleChips,
+				Player:  p.Idx,
+				Message: fmt.Sprintf("stack_size %d != initial_stack_size %d - wager %d", p.StackSize, p.InitialStackSize, p.Wager),
+			})
+		}
+	}
+
+	if gs.Status.CurrentRoundPot != wagers {
+		violations = append(violations, Violation{
+			Rule:    AuditRuleRoundPot,
+			Player:  -1,
+			Message: fmt.Sprintf("current_round_pot %d != wagers %d", gs.Status.CurrentRoundPot, wagers),
+		})
+	}
+
+	return violations
+}
+
+func auditPots(gs *GameState) []Violation {
+
+	violations := make([]Violation, 0)
+
+	var total int64
+	for i, p := range gs.Status.Pots {
+
+		if p == nil || p.Total < 0 {
+			violations = append(violations, Violation{
+				Rule:    AuditRulePots,
+				Player:  -1,
+				Message: fmt.Sprintf("invalid pot %d", i),
+			})
+			continue
+		}
+
+		total += p.Total
+	}
+
+	var pots int64
+	var wagers int64
+	var dead int64
+	for _, p := range gs.Players {
+		pots += p.Pot
+		wagers += p.Wager
+		dead += p.DeadBlind
+	}
+
+	// Pots are updated once round is closed, wagers are collected into pot of
+	// players when the next round begins
+	expected := pots
+	if gs.Status.CurrentEvent == "RoundClosed" {
+		expected += wagers
+	}
+
+	// Dead blinds get into pots with the first update after blinds, which is
+	// the end of preflop
+	if gs.Status.Round != "preflop" {
+		expected += dead
+	} else {
+		switch gs.Status.CurrentEvent {
+		case "RoundClosed", "GameCompleted", "SettlementRequested", "SettlementCompleted", "GameClosed":
+			expected += dead
+		}
+	}
+
+	if total != expected {
+		violations = append(violations, Violation{
+			Rule:    AuditRulePots,
+			Player:  -1,
+			Message: fmt.Sprintf("total of pots %d != %d (pot %d, wagers %d, dead blinds %d)", total, expected, pots, wagers, dead),
+		})
+	}
+
+	return violations
+}
+
+func auditCards(gs *GameState, s *Secrets) []Violation {
+
+	violations := make([]Violation, 0)
+	seen := make(map[card.Card]string)
+
+	check := func(where string, player int, cards []card.Card) {
+		for _, c := range cards {
+
+			if !c.IsValid() {
+				violations = append(violations, Violation{
+					Rule:    AuditRuleCards,
+					Player:  player,
+					Message: fmt.Sprintf("invalid card %s in %s", c, where),
+				})
+				continue
+			}
+
+			if prev, ok := seen[c]; ok {
+				violations = append(violations, Violation{
+					Rule:    AuditRuleCards,
+					Player:  player,
+					Message: fmt.Sprintf("duplicate card %s in %s and %s", c, prev, where),
+				})
+				continue
+			}
+
+			seen[c] = where
+		}
+	}
+
+	check("board", -1, gs.Status.Board)
+
+	// Hole cards are dealt at preflop
+	if len(gs.Status.Round) > 0 {
+		for _, p := range gs.Players {
+			check(fmt.Sprintf("hole cards of player %d", p.Idx), p.Idx, p.HoleCards)
+		}
+	}
+
+	// Legacy state keeps the whole deck
+	if pos := gs.Status.CurrentDeckPosition; pos >= 0 && pos < len(gs.Meta.Deck) {
+		check("deck", -1, gs.Meta.Deck[pos:])
+	}
+
+	check("burned", -1, gs.Status.Burned)
+
+	if s != nil {
+		check("deck", -1, s.Deck)
+		check("burned", -1, s.Burned)
+	}
+
+	return violations
+}
+
+func auditAllowedActions(gs *GameState) []Violation {
+
+	violations := make([]Violation, 0)
+
+	for _, p := range gs.Players {
+
+		actions := make([]string, 0)
+		for _, a := range p.AllowedActions {
+			for _, ba := range bettingActions {
+				if a == ba {
+					actions = append(actions, a)
+				}
+			}
+		}
+
+		if len(actions) == 0 {
+			continue
+		}
+
+		if p.Fold {
+			violations = append(violations, Violation{
+				Rule:    AuditRuleAllowedActions,
+				Player:  p.Idx,
+				Message: fmt.Sprintf("folded player is allowed to %s", strings.Join(actions, ",")),
+			})
+			continue
+		}
+
+		if gs.Status.CurrentEvent != "RoundStarted" || p.Idx != gs.Status.CurrentPlayer {
+			violations = append(violations, Violation{
+				Rule:    AuditRuleAllowedActions,
+				Player:  p.Idx,
+				Message: fmt.Sprintf("player is allowed to %s but not the current player", strings.Join(actions, ",")),
+			})
+		}
+	}
+
+	return violations
+}
+
+func auditSettlement(gs *GameState) []Violation {
+
+	violations := make([]Violation, 0)
+
+	if gs.Result == nil {
+		return violations
+	}
+
+	var changed int64
+	for _, pr := range gs.Result.Players {
+
+		changed += pr.Changed
+
+		p := gs.GetPlayer(pr.Idx)
+		if p == nil {
+			violations = append(violations, Violation{
+				Rule:    AuditRuleSettlement,
+				Player:  pr.Idx,
+				Message: "unknown player in result",
+			})
+			continue
+		}
+
+		if pr.Final != p.Bankroll+pr.Changed || pr.Final < 0 {
+			violations = append(violations, Violation{
+				Rule:    AuditRuleSettlement,
+				Player:  pr.Idx,
+				Message: fmt.Sprintf("final %d != bankroll %d + changed %d", pr.Final, p.Bankroll, pr.Changed),
+			})
+		}
+	}
+
+	if changed != 0 {
+		violations = append(violations, Violation{
+			Rule:    AuditRuleSettlement,
+			Player:  -1,
+			Message: fmt.Sprintf("changes sum to %d", changed),
+		})
+	}
+
+	return violations
+}
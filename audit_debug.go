@@ -0,0 +1,7 @@
+//go:build pokerface_debug
+
+package pokerface
+
+// Games are audited after every event in debug builds, violations panic if
+// no auditor was set.
+const auditAlways = true
@@ -0,0 +1,5 @@
+//go:build !pokerface_debug
+
+package pokerface
+
+const auditAlways = false
@@ -0,0 +1,239 @@
+package card
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrInvalidCard = errors.New("card: invalid card")
+)
+
+// Card is a compact representation of a card, the high nibble is suit and the
+// low nibble is rank which is aligned to zero (2 => 0, A => 12).
+//
+// Jokers take the slot after ace of spade (black joker) and heart (red joker),
+// they have no rank.
+type Card uint8
+
+type Suit uint8
+
+const (
+	SuitSpade Suit = iota
+	SuitHeart
+	SuitDiamond
+	SuitClub
+)
+
+const (
+	MinRank = 2
+	MaxRank = 14
+)
+
+const (
+	JokerBlack Card = Card(SuitSpade)<<4 | 0x0d
+	JokerRed   Card = Card(SuitHeart)<<4 | 0x0d
+)
+
+var JokerSymbols = map[Card]string{
+	JokerBlack: "JB",
+	JokerRed:   "JR",
+}
+
+var JokerBySymbol = map[string]Card{
+	"JB": JokerBlack,
+	"JR": JokerRed,
+}
+
+var Suits = []Suit{
+	SuitSpade,
+	SuitHeart,
+	SuitDiamond,
+	SuitClub,
+}
+
+var SuitSymbols = map[Suit]string{
+	SuitSpade:   "S",
+	SuitHeart:   "H",
+	SuitDiamond: "D",
+	SuitClub:    "C",
+}
+
+var SuitBySymbol = map[string]Suit{
+	"S": SuitSpade,
+	"H": SuitHeart,
+	"D": SuitDiamond,
+	"C": SuitClub,
+}
+
+var RankSymbols = map[int]string{
+	2:  "2",
+	3:  "3",
+	4:  "4",
+	5:  "5",
+	6:  "6",
+	7:  "7",
+	8:  "8",
+	9:  "9",
+	10: "T",
+	11: "J",
+	12: "Q",
+	13: "K",
+	14: "A",
+}
+
+var RankBySymbol = map[string]int{
+	"2": 2,
+	"3": 3,
+	"4": 4,
+	"5": 5,
+	"6": 6,
+	"7": 7,
+	"8": 8,
+	"9": 9,
+	"T": 10,
+	"J": 11,
+	"Q": 12,
+	"K": 13,
+	"A": 14,
+}
+
+func (s Suit) String() string {
+	return SuitSymbols[s]
+}
+
+func New(rank int, suit Suit) (Card, error) {
+
+	if rank < MinRank || rank > MaxRank || suit > SuitClub {
+		return 0, ErrInvalidCard
+	}
+
+	return Card(uint8(suit)<<4 | uint8(rank-MinRank)), nil
+}
+
+// Parse parses card in string notation which is suit first (e.g. "SA", "HT"),
+// jokers are "JB" and "JR".
+func Parse(symbol string) (Card, error) {
+
+	if len(symbol) != 2 {
+		return 0, ErrInvalidCard
+	}
+
+	if joker, ok := JokerBySymbol[symbol]; ok {
+		return joker, nil
+	}
+
+	suit, ok := SuitBySymbol[symbol[0:1]]
+	if !ok {
+		return 0, ErrInvalidCard
+	}
+
+	rank, ok := RankBySymbol[symbol[1:2]]
+	if !ok {
+		return 0, ErrInvalidCard
+	}
+
+	return New(rank, suit)
+}
+
+func MustParse(symbol string) Card {
+
+	c, err := Parse(symbol)
+	if err != nil {
+		panic(fmt.Sprintf("%v: %q", err, symbol))
+	}
+
+	return c
+}
+
+func ParseCards(symbols []string) ([]Card, error) {
+
+	cards := make([]Card, 0, len(symbols))
+	for _, s := range symbols {
+		c, err := Parse(s)
+		if err != nil {
+			return nil, err
+		}
+
+		cards = append(cards, c)
+	}
+
+	return cards, nil
+}
+
+func MustParseCards(symbols ...string) []Card {
+
+	cards := make([]Card, 0, len(symbols))
+	for _, s := range symbols {
+		cards = append(cards, MustParse(s))
+	}
+
+	return cards
+}
+
+// Strings converts cards to string notation.
+func Strings(cards []Card) []string {
+
+	symbols := make([]string, 0, len(cards))
+	for _, c := range cards {
+		symbols = append(symbols, c.String())
+	}
+
+	return symbols
+}
+
+func (c Card) IsValid() bool {
+	return c.IsJoker() || (c>>4 <= Card(SuitClub) && c&0x0f <= MaxRank-MinRank)
+}
+
+func (c Card) IsJoker() bool {
+	return c == JokerBlack || c == JokerRed
+}
+
+// Rank returns 0 if card is joker
+func (c Card) Rank() int {
+
+	if c.IsJoker() {
+		return 0
+	}
+
+	return int(c&0x0f) + MinRank
+}
+
+func (c Card) Suit() Suit {
+	return Suit(c >> 4)
+}
+
+func (c Card) String() string {
+
+	if !c.IsValid() {
+		return "??"
+	}
+
+	if c.IsJoker() {
+		return JokerSymbols[c]
+	}
+
+	return c.Suit().String() + RankSymbols[c.Rank()]
+}
+
+func (c Card) MarshalText() ([]byte, error) {
+
+	if !c.IsValid() {
+		return nil, ErrInvalidCard
+	}
+
+	return []byte(c.String()), nil
+}
+
+func (c *Card) UnmarshalText(data []byte) error {
+
+	card, err := Parse(string(data))
+	if err != nil {
+		return err
+	}
+
+	*c = card
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package card
+
+import (
+	"encoding/json"
+	"math/bits"
+	"strings"
+)
+
+// CardSet is a bitmask of cards, every suit takes 16 bits and rank is the bit
+// position inside it.
+type CardSet uint64
+
+func NewCardSet(cards ...Card) CardSet {
+	return CardSet(0).Add(cards...)
+}
+
+func (cs CardSet) Add(cards ...Card) CardSet {
+
+	for _, c := range cards {
+		cs |= 1 << c
+	}
+
+	return cs
+}
+
+func (cs CardSet) Remove(cards ...Card) CardSet {
+
+	for _, c := range cards {
+		cs &^= 1 << c
+	}
+
+	return cs
+}
+
+func (cs CardSet) Contains(c Card) bool {
+	return cs&(1<<c) != 0
+}
+
+func (cs CardSet) ContainsAll(other CardSet) bool {
+	return cs&other == other
+}
+
+func (cs CardSet) Union(other CardSet) CardSet {
+	return cs | other
+}
+
+func (cs CardSet) Intersect(other CardSet) CardSet {
+	return cs & other
+}
+
+func (cs CardSet) Difference(other CardSet) CardSet {
+	return cs &^ other
+}
+
+func (cs CardSet) Count() int {
+	return bits.OnesCount64(uint64(cs))
+}
+
+func (cs CardSet) IsEmpty() bool {
+	return cs == 0
+}
+
+// RankMask returns ranks of the suit without jokers, the lowest bit is rank 2.
+func (cs CardSet) RankMask(s Suit) uint16 {
+	return uint16(cs>>(uint(s)*16)) & 0x1fff
+}
+
+func (cs CardSet) Jokers() []Card {
+
+	jokers := make([]Card, 0)
+	for _, j := range []Card{JokerBlack, JokerRed} {
+		if cs.Contains(j) {
+			jokers = append(jokers, j)
+		}
+	}
+
+	return jokers
+}
+
+// Cards returns cards of set in order of suit and rank.
+func (cs CardSet) Cards() []Card {
+
+	cards := make([]Card, 0, cs.Count())
+	for v := uint64(cs); v != 0; v &= v - 1 {
+		cards = append(cards, Card(bits.TrailingZeros64(v)))
+	}
+
+	return cards
+}
+
+func (cs CardSet) String() string {
+	return strings.Join(Strings(cs.Cards()), ",")
+}
+
+func (cs CardSet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(cs.Cards())
+}
+
+func (cs *CardSet) UnmarshalJSON(data []byte) error {
+
+	var cards []Card
+	err := json.Unmarshal(data, &cards)
+	if err != nil {
+		return err
+	}
+
+	*cs = NewCardSet(cards...)
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package card
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCardSet_Operations(t *testing.T) {
+
+	cs := NewCardSet(MustParseCards("S2", "SA", "HK")...)
+	assert.Equal(t, 3, cs.Count())
+	assert.True(t, cs.Contains(MustParse("SA")))
+	assert.False(t, cs.Contains(MustParse("DA")))
+
+	cs = cs.Add(MustParse("DA"))
+	assert.Equal(t, 4, cs.Count())
+
+	cs = cs.Remove(MustParse("S2"))
+	assert.False(t, cs.Contains(MustParse("S2")))
+	assert.Equal(t, 3, cs.Count())
+
+	other := NewCardSet(MustParseCards("SA", "C9")...)
+	assert.Equal(t, NewCardSet(MustParseCards("SA", "HK", "DA", "C9")...), cs.Union(other))
+	assert.Equal(t, NewCardSet(MustParse("SA")), cs.Intersect(other))
+	assert.Equal(t, NewCardSet(MustParseCards("HK", "DA")...), cs.Difference(other))
+	assert.True(t, cs.ContainsAll(NewCardSet(MustParseCards("HK", "DA")...)))
+	assert.False(t, cs.ContainsAll(other))
+
+	assert.True(t, CardSet(0).IsEmpty())
+	assert.False(t, cs.IsEmpty())
+}
+
+func TestCardSet_Cards(t *testing.T) {
+
+	cs := NewCardSet(MustParseCards("CA", "S2", "HK", "S9")...)
+
+	assert.Equal(t, MustParseCards("S2", "S9", "HK", "CA"), cs.Cards())
+	assert.Equal(t, "S2,S9,HK,CA", cs.String())
+	assert.Equal(t, uint16(1<<0|1<<7), cs.RankMask(SuitSpade))
+	assert.Equal(t, uint16(1<<12), cs.RankMask(SuitClub))
+	assert.Equal(t, uint16(0), cs.RankMask(SuitDiamond))
+}
+
+func TestCardSet_JSON(t *testing.T) {
+
+	cs := NewCardSet(MustParseCards("HK", "S2")...)
+
+	data, err := json.Marshal(cs)
+	assert.Nil(t, err)
+	assert.Equal(t, `["S2","HK"]`, string(data))
+
+	var decoded CardSet
+	assert.Nil(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, cs, decoded)
+}
+
+func TestCardSet_Jokers(t *testing.T) {
+
+	cs := NewCardSet(MustParseCards("SA", "JB", "JR", "HA")...)
+
+	assert.Equal(t, 4, cs.Count())
+	assert.Equal(t, []Card{JokerBlack, JokerRed}, cs.Jokers())
+	assert.Equal(t, uint16(1<<12), cs.RankMask(SuitSpade))
+	assert.Equal(t, uint16(1<<12), cs.RankMask(SuitHeart))
+	assert.Equal(t, "SA,JB,HA,JR", cs.String())
+}
@@ -0,0 +1,76 @@
+package card
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+
+	for _, s := range Suits {
+		for r := MinRank; r <= MaxRank; r++ {
+
+			symbol := SuitSymbols[s] + RankSymbols[r]
+
+			c, err := Parse(symbol)
+			assert.Nil(t, err)
+			assert.True(t, c.IsValid())
+			assert.Equal(t, r, c.Rank())
+			assert.Equal(t, s, c.Suit())
+			assert.Equal(t, symbol, c.String())
+		}
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+
+	symbols := []string{"", "S", "AS", "X2", "S1", "SA1", "sa"}
+
+	for _, symbol := range symbols {
+		_, err := Parse(symbol)
+		assert.Equal(t, ErrInvalidCard, err, symbol)
+	}
+
+	_, err := ParseCards([]string{"S2", "Z9"})
+	assert.Equal(t, ErrInvalidCard, err)
+
+	_, err = New(15, SuitSpade)
+	assert.Equal(t, ErrInvalidCard, err)
+
+	assert.False(t, Card(0x0f).IsValid())
+	assert.False(t, Card(0x40).IsValid())
+}
+
+func TestCard_JSON(t *testing.T) {
+
+	cards := MustParseCards("S2", "HT", "DA")
+
+	data, err := json.Marshal(cards)
+	assert.Nil(t, err)
+	assert.Equal(t, `["S2","HT","DA"]`, string(data))
+
+	var decoded []Card
+	assert.Nil(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, cards, decoded)
+
+	assert.NotNil(t, json.Unmarshal([]byte(`["S2","X2"]`), &decoded))
+}
+
+func TestParse_Joker(t *testing.T) {
+
+	for symbol, joker := range JokerBySymbol {
+
+		c, err := Parse(symbol)
+		assert.Nil(t, err)
+		assert.Equal(t, joker, c)
+		assert.True(t, c.IsValid())
+		assert.True(t, c.IsJoker())
+		assert.Equal(t, 0, c.Rank())
+		assert.Equal(t, symbol, c.String())
+	}
+
+	assert.False(t, MustParse("SA").IsJoker())
+	assert.False(t, Card(0x2d).IsValid())
+}
@@ -0,0 +1,369 @@
+package pokerface
+
+import (
+	"sort"
+
+	"github.com/weedbox/pokerface/codec"
+	"github.com/weedbox/pokerface/combination"
+	"github.com/weedbox/pokerface/pot"
+	"github.com/weedbox/pokerface/settlement"
+)
+
+// MarshalGameState encodes game state in compact binary format, the result
+// is the same as JSON after decoding.
+func MarshalGameState(gs *GameState) ([]byte, error) {
+
+	e := codec.NewEncoder()
+	e.WriteHeader(codec.KindGameState)
+	EncodeGameState(e, gs)
+
+	return e.Bytes(), nil
+}
+
+func UnmarshalGameState(data []byte) (*GameState, error) {
+
+	d := codec.NewDecoder(data)
+	d.ReadHeader(codec.KindGameState)
+	gs := DecodeGameState(d)
+
+	if err := d.Finish(); err != nil {
+		return nil, err
+	}
+
+	return gs, nil
+}
+
+// EncodeGameState writes game state which could be nil
+func EncodeGameState(e *codec.Encoder, gs *GameState) {
+
+	e.WriteBool(gs != nil)
+	if gs == nil {
+		return
+	}
+
+	if e.Version() >= 2 {
+		e.WriteInt(gs.SchemaVersion)
+	}
+
+	e.WriteString(gs.GameID)
+	e.WriteVarint(gs.CreatedAt)
+	e.WriteVarint(gs.UpdatedAt)
+	encodeMeta(e, &gs.Meta)
+	encodeStatus(e, &gs.Status)
+
+	e.WriteLength(len(gs.Players), gs.Players == nil)
+	for _, p := range gs.Players {
+		encodePlayerState(e, p)
+	}
+
+	settlement.EncodeResult(e, gs.Result)
+}
+
+func DecodeGameState(d *codec.Decoder) *GameState {
+
+	if !d.ReadBool() {
+		return nil
+	}
+
+	gs := &GameState{}
+
+	// Schema version is not available before version 2
+	if d.Version() >= 2 {
+		gs.SchemaVersion = d.ReadInt()
+	}
+
+	gs.GameID = d.ReadString()
+	gs.CreatedAt = d.ReadVarint()
+	gs.UpdatedAt = d.ReadVarint()
+
+	decodeMeta(d, &gs.Meta)
+	decodeStatus(d, &gs.Status)
+
+	if length := d.ReadLength(); length >= 0 {
+		gs.Players = make([]*PlayerState, length)
+		for i := range gs.Players {
+			gs.Players[i] = decodePlayerState(d)
+		}
+	}
+
+	gs.Result = settlement.DecodeResult(d)
+
+	return gs
+}
+
+func encodeMeta(e *codec.Encoder, m *Meta) {
+
+	e.WriteVarint(m.Ante)
+	e.WriteVarint(m.Blind.Dealer)
+	e.WriteVarint(m.Blind.SB)
+	e.WriteVarint(m.Blind.BB)
+	e.WriteString(m.Limit)
+	e.WriteInt(m.HoleCardsCount)
+	e.WriteInt(m.RequiredHoleCardsCount)
+
+	e.WriteLength(len(m.CombinationPowers), m.CombinationPowers == nil)
+	for _, c := range m.CombinationPowers {
+		e.WriteVarint(int64(c))
+	}
+
+	e.WriteString(m.Evaluator)
+	e.WriteString(m.Locale)
+	e.WriteInts(m.WildRanks)
+	e.WriteCards(m.Deck)
+	e.WriteInt(m.BurnCount)
+
+	if e.Version() >= 4 {
+		e.WriteString(m.CardSource)
+	}
+
+	if e.Version() >= 5 {
+		e.WriteString(m.MisdealLimit)
+	}
+}
+
+func decodeMeta(d *codec.Decoder, m *Meta) {
+
+	m.Ante = d.ReadVarint()
+	m.Blind.Dealer = d.ReadVarint()
+	m.Blind.SB = d.ReadVarint()
+	m.Blind.BB = d.ReadVarint()
+	m.Limit = d.ReadString()
+	m.HoleCardsCount = d.ReadInt()
+	m.RequiredHoleCardsCount = d.ReadInt()
+
+	if length := d.ReadLength(); length >= 0 {
+		m.CombinationPowers = make(combination.PowerRankings, length)
+		for i := range m.CombinationPowers {
+			m.CombinationPowers[i] = combination.Combination(d.ReadVarint())
+		}
+	}
+
+	m.Evaluator = d.ReadString()
+	m.Locale = d.ReadString()
+	m.WildRanks = d.ReadInts()
+	m.Deck = d.ReadCards()
+	m.BurnCount = d.ReadInt()
+
+	if d.Version() >= 4 {
+		m.CardSource = d.ReadString()
+	}
+
+	if d.Version() >= 5 {
+		m.MisdealLimit = d.ReadString()
+	}
+}
+
+func encodeStatus(e *codec.Encoder, s *Status) {
+
+	e.WriteVarint(s.MiniBet)
+	e.WriteVarint(s.MaxWager)
+
+	e.WriteLength(len(s.Pots), s.Pots == nil)
+	for _, p := range s.Pots {
+		encodePot(e, p)
+	}
+
+	e.WriteString(s.Round)
+	e.WriteCards(s.Burned)
+	e.WriteCards(s.Board)
+	e.WriteVarint(s.PreviousRaiseSize)
+	e.WriteInt(s.CurrentDeckPosition)
+	e.WriteVarint(s.CurrentRoundPot)
+	e.WriteVarint(s.CurrentWager)
+	e.WriteInt(s.CurrentRaiser)
+	e.WriteInt(s.CurrentPlayer)
+	e.WriteString(s.CurrentEvent)
+
+	e.WriteBool(s.LastAction != nil)
+	if s.LastAction != nil {
+		e.WriteInt(s.LastAction.Source)
+		e.WriteString(s.LastAction.Type)
+		e.WriteVarint(s.LastAction.Value)
+	}
+
+	if e.Version() >= 4 {
+		e.WriteBool(s.CardsRequest != nil)
+		if s.CardsRequest != nil {
+			e.WriteString(s.CardsRequest.Round)
+			e.WriteInt(s.CardsRequest.Count)
+			e.WriteCards(s.CardsRequest.Cards)
+		}
+	}
+
+	if e.Version() >= 5 {
+		e.WriteString(s.VoidReason)
+	}
+}
+
+func decodeStatus(d *codec.Decoder, s *Status) {
+
+	s.MiniBet = d.ReadVarint()
+	s.MaxWager = d.ReadVarint()
+
+	if length := d.ReadLength(); length >= 0 {
+		s.Pots = make([]*pot.Pot, length)
+		for i := range s.Pots {
+			s.Pots[i] = decodePot(d)
+		}
+	}
+
+	s.Round = d.ReadString()
+	s.Burned = d.ReadCards()
+	s.Board = d.ReadCards()
+	s.PreviousRaiseSize = d.ReadVarint()
+	s.CurrentDeckPosition = d.ReadInt()
+	s.CurrentRoundPot = d.ReadVarint()
+	s.CurrentWager = d.ReadVarint()
+	s.CurrentRaiser = d.ReadInt()
+	s.CurrentPlayer = d.ReadInt()
+	s.CurrentEvent = d.ReadString()
+
+	if d.ReadBool() {
+		s.LastAction = &Action{
+			Source: d.ReadInt(),
+			Type:   d.ReadString(),
+			Value:  d.ReadVarint(),
+		}
+	}
+
+	if d.Version() >= 4 && d.ReadBool() {
+		s.CardsRequest = &CardsRequest{
+			Round: d.ReadString(),
+			Count: d.ReadInt(),
+			Cards: d.ReadCards(),
+		}
+	}
+
+	if d.Version() >= 5 {
+		s.VoidReason = d.ReadString()
+	}
+}
+
+// Levels of pot is not a part of state, so it is ignored as JSON does.
+func encodePot(e *codec.Encoder, p *pot.Pot) {
+
+	e.WriteBool(p != nil)
+	if p == nil {
+		return
+	}
+
+	e.WriteVarint(p.Level)
+	e.WriteVarint(p.Wager)
+	e.WriteVarint(p.Total)
+
+	// Keys are sorted to make sure output is always the same
+	keys := make([]int, 0, len(p.Contributors))
+	for k := range p.Contributors {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+
+	e.WriteLength(len(keys), p.Contributors == nil)
+	for _, k := range keys {
+		e.WriteInt(k)
+		e.WriteVarint(p.Contributors[k])
+	}
+}
+
+func decodePot(d *codec.Decoder) *pot.Pot {
+
+	if !d.ReadBool() {
+		return nil
+	}
+
+	p := &pot.Pot{
+		Level: d.ReadVarint(),
+		Wager: d.ReadVarint(),
+		Total: d.ReadVarint(),
+	}
+
+	if length := d.ReadLength(); length >= 0 {
+		p.Contributors = make(map[int]int64, length)
+		for i := 0; i < length; i++ {
+			k := d.ReadInt()
+			p.Contributors[k] = d.ReadVarint()
+		}
+	}
+
+	return p
+}
+
+func encodePlayerState(e *codec.Encoder, p *PlayerState) {
+
+	e.WriteBool(p != nil)
+	if p == nil {
+		return
+	}
+
+	e.WriteInt(p.Idx)
+	e.WriteStrings(p.Positions)
+	e.WriteBool(p.Acted)
+	e.WriteString(p.DidAction)
+	e.WriteBool(p.Fold)
+	e.WriteBool(p.VPIP)
+	e.WriteStrings(p.AllowedActions)
+	e.WriteVarint(p.Bankroll)
+	e.WriteVarint(p.InitialStackSize)
+	e.WriteVarint(p.StackSize)
+	e.WriteVarint(p.Pot)
+	e.WriteVarint(p.Wager)
+	e.WriteCards(p.HoleCards)
+
+	e.WriteBool(p.Combination != nil)
+	if p.Combination != nil {
+		e.WriteString(p.Combination.Type)
+		e.WriteCards(p.Combination.Cards)
+		e.WriteInt(p.Combination.Power)
+		e.WriteString(p.Combination.Description)
+	}
+
+	if e.Version() >= 3 {
+		e.WriteInts(p.ShownCards)
+	}
+
+	if e.Version() >= 12 {
+		e.WriteVarint(p.DeadBlind)
+	}
+}
+
+func decodePlayerState(d *codec.Decoder) *PlayerState {
+
+	if !d.ReadBool() {
+		return nil
+	}
+
+	p := &PlayerState{
+		Idx:              d.ReadInt(),
+		Positions:        d.ReadStrings(),
+		Acted:            d.ReadBool(),
+		DidAction:        d.ReadString(),
+		Fold:             d.ReadBool(),
+		VPIP:             d.ReadBool(),
+		AllowedActions:   d.ReadStrings(),
+		Bankroll:         d.ReadVarint(),
+		InitialStackSize: d.ReadVarint(),
+		StackSize:        d.ReadVarint(),
+		Pot:              d.ReadVarint(),
+		Wager:            d.ReadVarint(),
+		HoleCards:        d.ReadCards(),
+	}
+
+	if d.ReadBool() {
+		p.Combination = &CombinationInfo{
+			Type:        d.ReadString(),
+			Cards:       d.ReadCards(),
+			Power:       d.ReadInt(),
+			Description: d.ReadString(),
+		}
+	}
+
+	if d.Version() >= 3 {
+		p.ShownCards = d.ReadInts()
+	}
+
+	if d.Version() >= 12 {
+		p.DeadBlind = d.ReadVarint()
+	}
+
+	return p
+}
@@ -0,0 +1,413 @@
+package codec
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+
+	"github.com/weedbox/pokerface/card"
+)
+
+var (
+	ErrInvalidFormat      = errors.New("codec: invalid format")
+	ErrUnexpectedEOF      = errors.New("codec: unexpected end of data")
+	ErrUnsupportedVersion = errors.New("codec: unsupported version")
+	ErrUnexpectedKind     = errors.New("codec: unexpected kind")
+)
+
+// Version of binary format
+//
+// 1: initial format
+// 2: schema version of game state
+// 3: shown cards of players
+// 4: card source and cards request
+// 5: misdeal limit and void reason
+// 6: action deadline of table
+// 7: time bank of table
+// 8: sit out of table
+// 9: top-up of table
+// 10: cash game mode of table
+// 11: pushed cards in secrets
+// 12: dead blinds of players
+const Version = 12
+
+const magic = 0xfa
+
+type Kind byte
+
+const (
+	KindGameState Kind = iota + 1
+	KindTableState
+	KindResult
+	KindSecrets
+)
+
+// Encoder writes values in compact binary format. Integers are varints,
+// strings and slices are prefixed with length.
+type Encoder struct {
+	buf     []byte
+	version uint64
+}
+
+func NewEncoder() *Encoder {
+	return NewEncoderVersion(Version)
+}
+
+// NewEncoderVersion creates encoder for an older format version, so that
+// data is readable by peers which are not upgraded yet.
+func NewEncoderVersion(version uint64) *Encoder {
+	return &Encoder{
+		buf:     make([]byte, 0, 256),
+		version: version,
+	}
+}
+
+// Version returns format version of data to write
+func (e *Encoder) Version() uint64 {
+	return e.version
+}
+
+// WriteHeader writes magic number, format version and kind of data
+func (e *Encoder) WriteHeader(kind Kind) {
+	e.buf = append(e.buf, magic)
+	e.WriteUvarint(e.version)
+	e.buf = append(e.buf, byte(kind))
+}
+
+func (e *Encoder) Bytes() []byte {
+	return e.buf
+}
+
+func (e *Encoder) WriteUvarint(v uint64) {
+	e.buf = binary.AppendUvarint(e.buf, v)
+}
+
+func (e *Encoder) WriteVarint(v int64) {
+	e.buf = binary.AppendVarint(e.buf, v)
+}
+
+func (e *Encoder) WriteInt(v int) {
+	e.WriteVarint(int64(v))
+}
+
+func (e *Encoder) WriteBool(v bool) {
+	if v {
+		e.buf = append(e.buf, 1)
+	} else {
+		e.buf = append(e.buf, 0)
+	}
+}
+
+func (e *Encoder) WriteFloat64(v float64) {
+	e.buf = binary.LittleEndian.AppendUint64(e.buf, math.Float64bits(v))
+}
+
+func (e *Encoder) WriteString(v string) {
+	e.WriteUvarint(uint64(len(v)))
+	e.buf = append(e.buf, v...)
+}
+
+func (e *Encoder) WriteBytes(v []byte) {
+	e.WriteUvarint(uint64(len(v)))
+	e.buf = append(e.buf, v...)
+}
+
+// WriteLength writes length of slice or map, nil is different from empty
+// one so that JSON output is the same after decoding.
+func (e *Encoder) WriteLength(length int, isNil bool) {
+
+	if isNil {
+		e.WriteUvarint(0)
+		return
+	}
+
+	e.WriteUvarint(uint64(length) + 1)
+}
+
+func (e *Encoder) WriteStrings(v []string) {
+
+	e.WriteLength(len(v), v == nil)
+	for _, s := range v {
+		e.WriteString(s)
+	}
+}
+
+func (e *Encoder) WriteInts(v []int) {
+
+	e.WriteLength(len(v), v == nil)
+	for _, i := range v {
+		e.WriteInt(i)
+	}
+}
+
+// WriteCards writes a card in a byte
+func (e *Encoder) WriteCards(v []card.Card) {
+
+	e.WriteLength(len(v), v == nil)
+	for _, c := range v {
+		e.buf = append(e.buf, byte(c))
+	}
+}
+
+// Decoder reads values written by Encoder. The first error is kept and
+// zero values are returned after that, so that callers are able to check
+// error once at the end.
+type Decoder struct {
+	data    []byte
+	pos     int
+	err     error
+	version uint64
+}
+
+func NewDecoder(data []byte) *Decoder {
+	return &Decoder{
+		data: data,
+	}
+}
+
+// Version returns format version of data which is read by ReadHeader
+func (d *Decoder) Version() uint64 {
+	return d.version
+}
+
+func (d *Decoder) Err() error {
+	return d.err
+}
+
+func (d *Decoder) fail(err error) {
+	if d.err == nil {
+		d.err = err
+	}
+}
+
+// Remaining returns the number of bytes which are not read yet
+func (d *Decoder) Remaining() int {
+	return len(d.data) - d.pos
+}
+
+// ReadHeader checks magic number, format version and kind of data, it
+// returns format version.
+func (d *Decoder) ReadHeader(kind Kind) uint64 {
+
+	if d.readByte() != magic {
+		d.fail(ErrInvalidFormat)
+		return 0
+	}
+
+	version := d.ReadUvarint()
+	if d.err == nil && (version == 0 || version > Version) {
+		d.fail(ErrUnsupportedVersion)
+		return 0
+	}
+
+	if k := Kind(d.readByte()); d.err == nil && k != kind {
+		d.fail(ErrUnexpectedKind)
+		return 0
+	}
+
+	d.version = version
+
+	return version
+}
+
+func (d *Decoder) readByte() byte {
+
+	if d.err != nil {
+		return 0
+	}
+
+	if d.pos >= len(d.data) {
+		d.fail(ErrUnexpectedEOF)
+		return 0
+	}
+
+	b := d.data[d.pos]
+	d.pos++
+
+	return b
+}
+
+func (d *Decoder) ReadUvarint() uint64 {
+
+	if d.err != nil {
+		return 0
+	}
+
+	v, n := binary.Uvarint(d.data[d.pos:])
+	if n == 0 {
+		d.fail(ErrUnexpectedEOF)
+		return 0
+	} else if n < 0 {
+		d.fail(ErrInvalidFormat)
+		return 0
+	}
+
+	d.pos += n
+
+	return v
+}
+
+func (d *Decoder) ReadVarint() int64 {
+
+	if d.err != nil {
+		return 0
+	}
+
+	v, n := binary.Varint(d.data[d.pos:])
+	if n == 0 {
+		d.fail(ErrUnexpectedEOF)
+		return 0
+	} else if n < 0 {
+		d.fail(ErrInvalidFormat)
+		return 0
+	}
+
+	d.pos += n
+
+	return v
+}
+
+func (d *Decoder) ReadInt() int {
+	return int(d.ReadVarint())
+}
+
+func (d *Decoder) ReadBool() bool {
+
+	switch d.readByte() {
+	case 0:
+		return false
+	case 1:
+		return true
+	}
+
+	d.fail(ErrInvalidFormat)
+
+	return false
+}
+
+func (d *Decoder) ReadFloat64() float64 {
+
+	if d.err != nil {
+		return 0
+	}
+
+	if d.Remaining() < 8 {
+		d.fail(ErrUnexpectedEOF)
+		return 0
+	}
+
+	v := binary.LittleEndian.Uint64(d.data[d.pos:])
+	d.pos += 8
+
+	return math.Float64frombits(v)
+}
+
+func (d *Decoder) ReadBytes() []byte {
+
+	length := d.ReadUvarint()
+	if d.err != nil {
+		return nil
+	}
+
+	if length > uint64(d.Remaining()) {
+		d.fail(ErrUnexpectedEOF)
+		return nil
+	}
+
+	v := make([]byte, length)
+	copy(v, d.data[d.pos:])
+	d.pos += int(length)
+
+	return v
+}
+
+func (d *Decoder) ReadString() string {
+	return string(d.ReadBytes())
+}
+
+// ReadLength reads length written by WriteLength, it returns -1 for nil.
+// Every element takes one byte at least, so length is never greater than
+// remaining data.
+func (d *Decoder) ReadLength() int {
+
+	length := d.ReadUvarint()
+	if d.err != nil {
+		return -1
+	}
+
+	if length == 0 {
+		return -1
+	}
+
+	if length-1 > uint64(d.Remaining()) {
+		d.fail(ErrUnexpectedEOF)
+		return -1
+	}
+
+	return int(length - 1)
+}
+
+func (d *Decoder) ReadStrings() []string {
+
+	length := d.ReadLength()
+	if length < 0 {
+		return nil
+	}
+
+	v := make([]string, length)
+	for i := range v {
+		v[i] = d.ReadString()
+	}
+
+	return v
+}
+
+func (d *Decoder) ReadInts() []int {
+
+	length := d.ReadLength()
+	if length < 0 {
+		return nil
+	}
+
+	v := make([]int, length)
+	for i := range v {
+		v[i] = d.ReadInt()
+	}
+
+	return v
+}
+
+func (d *Decoder) ReadCards() []card.Card {
+
+	length := d.ReadLength()
+	if length < 0 {
+		return nil
+	}
+
+	v := make([]card.Card, length)
+	for i := range v {
+
+		c := card.Card(d.readByte())
+		if d.err == nil && !c.IsValid() {
+			d.fail(ErrInvalidFormat)
+		}
+
+		v[i] = c
+	}
+
+	return v
+}
+
+// Finish makes sure all of data was consumed
+func (d *Decoder) Finish() error {
+
+	if d.err != nil {
+		return d.err
+	}
+
+	if d.Remaining() != 0 {
+		return ErrInvalidFormat
+	}
+
+	return nil
+}
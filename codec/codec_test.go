@@ -0,0 +1,98 @@
+package codec
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokerface/card"
+)
+
+func TestEncoder_RoundTrip(t *testing.T) {
+
+	e := NewEncoder()
+	e.WriteHeader(KindGameState)
+	e.WriteUvarint(math.MaxUint64)
+	e.WriteVarint(math.MinInt64)
+	e.WriteInt(-1)
+	e.WriteBool(true)
+	e.WriteFloat64(0.25)
+	e.WriteString("pokerface")
+	e.WriteStrings(nil)
+	e.WriteStrings([]string{})
+	e.WriteInts([]int{1, -2, 300})
+	e.WriteCards(card.MustParseCards("SA", "JR"))
+
+	d := NewDecoder(e.Bytes())
+	assert.Equal(t, uint64(Version), d.ReadHeader(KindGameState))
+	assert.Equal(t, uint64(math.MaxUint64), d.ReadUvarint())
+	assert.Equal(t, int64(math.MinInt64), d.ReadVarint())
+	assert.Equal(t, -1, d.ReadInt())
+	assert.True(t, d.ReadBool())
+	assert.Equal(t, 0.25, d.ReadFloat64())
+	assert.Equal(t, "pokerface", d.ReadString())
+	assert.Nil(t, d.ReadStrings())
+	assert.Equal(t, []string{}, d.ReadStrings())
+	assert.Equal(t, []int{1, -2, 300}, d.ReadInts())
+	assert.Equal(t, card.MustParseCards("SA", "JR"), d.ReadCards())
+	assert.Nil(t, d.Finish())
+}
+
+func TestEncoder_OlderVersion(t *testing.T) {
+
+	e := NewEncoderVersion(1)
+	e.WriteHeader(KindResult)
+	assert.Equal(t, uint64(1), e.Version())
+
+	d := NewDecoder(e.Bytes())
+	assert.Equal(t, uint64(1), d.ReadHeader(KindResult))
+	assert.Equal(t, uint64(1), d.Version())
+	assert.Nil(t, d.Finish())
+}
+
+func TestDecoder_Errors(t *testing.T) {
+
+	e := NewEncoder()
+	e.WriteHeader(KindResult)
+	e.WriteString("pokerface")
+	data := e.Bytes()
+
+	// Wrong kind
+	d := NewDecoder(data)
+	d.ReadHeader(KindGameState)
+	assert.Equal(t, ErrUnexpectedKind, d.Err())
+
+	// Newer version
+	d = NewDecoder([]byte{magic, Version + 1, byte(KindResult)})
+	d.ReadHeader(KindResult)
+	assert.Equal(t, ErrUnsupportedVersion, d.Err())
+
+	// Not encoded by codec
+	d = NewDecoder([]byte(`{"game_id":""}`))
+	d.ReadHeader(KindResult)
+	assert.Equal(t, ErrInvalidFormat, d.Err())
+
+	// Truncated data
+	d = NewDecoder(data[:len(data)-1])
+	d.ReadHeader(KindResult)
+	assert.Equal(t, "", d.ReadString())
+	assert.Equal(t, ErrUnexpectedEOF, d.Finish())
+
+	// Huge length should not be allocated
+	e = NewEncoder()
+	e.WriteUvarint(math.MaxUint32)
+	d = NewDecoder(e.Bytes())
+	assert.Equal(t, -1, d.ReadLength())
+	assert.Equal(t, ErrUnexpectedEOF, d.Err())
+
+	// Invalid card
+	d = NewDecoder([]byte{2, 0xff})
+	d.ReadCards()
+	assert.Equal(t, ErrInvalidFormat, d.Err())
+
+	// Trailing data
+	d = NewDecoder(append(data, 0))
+	d.ReadHeader(KindResult)
+	d.ReadString()
+	assert.Equal(t, ErrInvalidFormat, d.Finish())
+}
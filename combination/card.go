@@ -2,11 +2,16 @@ package combination
 
 import (
 	"fmt"
+
+	"github.com/weedbox/pokerface/card"
 )
 
 type Card struct {
 	Suit string
 	Rank int
+
+	// Wild card which is substituted by this card
+	Wild *card.Card
 }
 
 var CardRank = map[string]int{
@@ -48,11 +53,34 @@ var SuitSymbol = map[int]string{
 	4: "C", // Club
 }
 
-func GetCardState(card string) *Card {
+func NewCardState(c card.Card) *Card {
+	return &Card{
+		Suit: c.Suit().String(),
+		Rank: c.Rank(),
+	}
+}
+
+func ParseCardState(symbol string) (*Card, error) {
 
-	c := &Card{
-		Suit: card[0:1],
-		Rank: CardRank[card[1:2]],
+	c, err := card.Parse(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	// Joker has no rank so that it must be substituted before calculation
+	if c.IsJoker() {
+		return nil, card.ErrInvalidCard
+	}
+
+	return NewCardState(c), nil
+}
+
+// GetCardState returns an empty card if symbol is invalid
+func GetCardState(symbol string) *Card {
+
+	c, err := ParseCardState(symbol)
+	if err != nil {
+		return &Card{}
 	}
 
 	return c
@@ -72,3 +100,18 @@ func GetCardStates(cardSymbols []string) []*Card {
 func (c *Card) ToString() string {
 	return fmt.Sprintf("%s%s", c.Suit, CardSymbol[c.Rank])
 }
+
+// ToCard returns the original wild card if card is a substitution.
+func (c *Card) ToCard() (card.Card, error) {
+
+	if c.Wild != nil {
+		return *c.Wild, nil
+	}
+
+	suit, ok := card.SuitBySymbol[c.Suit]
+	if !ok {
+		return 0, card.ErrInvalidCard
+	}
+
+	return card.New(c.Rank, suit)
+}
@@ -15,3 +15,21 @@ func TestGetCardStates(t *testing.T) {
 		assert.Equal(t, cs, cards[i].ToString())
 	}
 }
+
+func TestParseCardState(t *testing.T) {
+
+	c, err := ParseCardState("HT")
+	assert.Nil(t, err)
+	assert.Equal(t, "H", c.Suit)
+	assert.Equal(t, 10, c.Rank)
+
+	code, err := c.ToCard()
+	assert.Nil(t, err)
+	assert.Equal(t, "HT", code.String())
+
+	_, err = ParseCardState("H1")
+	assert.NotNil(t, err)
+
+	// Invalid card never panics
+	assert.Equal(t, &Card{}, GetCardState(""))
+}
@@ -12,6 +12,7 @@ const (
 	CombinationFullHouse
 	CombinationFourOfAKind
 	CombinationStraightFlush
+	CombinationFiveOfAKind
 )
 
 var CombinationSymbol = map[Combination]string{
@@ -24,6 +25,7 @@ var CombinationSymbol = map[Combination]string{
 	CombinationFullHouse:     "FullHouse",
 	CombinationFourOfAKind:   "FourOfAKind",
 	CombinationStraightFlush: "StraightFlush",
+	CombinationFiveOfAKind:   "FiveOfAKind",
 }
 
 // Power score of combination:
@@ -36,6 +38,7 @@ var CombinationSymbol = map[Combination]string{
 // FullHouse		: 13(toak) * 12(pair) ~= 13^2				= 169
 // FourOfAKind		: 13(foak) * 12 ~= 13^2						= 169
 // StraightFlush	: 10(5~A) ~= 13^1							= 13
+// FiveOfAKind		: 13(fiveoak) ~= 13^1						= 13
 
 var CombinationLevel = map[Combination]uint64{
 	CombinationHighCard:      371293,
@@ -47,6 +50,7 @@ var CombinationLevel = map[Combination]uint64{
 	CombinationFullHouse:     169,
 	CombinationFourOfAKind:   169,
 	CombinationStraightFlush: 13,
+	CombinationFiveOfAKind:   13,
 }
 
 // Combination ranking table for different game rules
@@ -76,6 +80,46 @@ var CombinationPowerShortDeck = []Combination{
 	CombinationStraightFlush,
 }
 
+// Five of a kind is only possible with wild cards, it is the best combination
+// in most of rules.
+var CombinationPowerWild = []Combination{
+	CombinationHighCard,
+	CombinationPair,
+	CombinationTwoPair,
+	CombinationThreeOfAKind,
+	CombinationStraight,
+	CombinationFlush,
+	CombinationFullHouse,
+	CombinationFourOfAKind,
+	CombinationStraightFlush,
+	CombinationFiveOfAKind,
+}
+
+// Lowball rankings are used with lowball evaluators, weaker combinations
+// are stronger in these rules.
+var CombinationPowerLowballA5 = []Combination{
+	CombinationFiveOfAKind,
+	CombinationFourOfAKind,
+	CombinationFullHouse,
+	CombinationThreeOfAKind,
+	CombinationTwoPair,
+	CombinationPair,
+	CombinationHighCard,
+}
+
+var CombinationPowerLowball27 = []Combination{
+	CombinationFiveOfAKind,
+	CombinationStraightFlush,
+	CombinationFourOfAKind,
+	CombinationFullHouse,
+	CombinationFlush,
+	CombinationStraight,
+	CombinationThreeOfAKind,
+	CombinationTwoPair,
+	CombinationPair,
+	CombinationHighCard,
+}
+
 func gospersHack(k int, n int) []int {
 
 	result := make([]int, 0)
@@ -106,9 +150,9 @@ func binaryOnesPositions(value int, n int) []int {
 	return positions
 }
 
-func GetPossibleCombinations(cards []string, n int) [][]string {
+func GetPossibleCombinations[T any](cards []T, n int) [][]T {
 
-	combinations := make([][]string, 0)
+	combinations := make([][]T, 0)
 
 	total := len(cards)
 	if total <= n {
@@ -120,7 +164,7 @@ func GetPossibleCombinations(cards []string, n int) [][]string {
 
 	for _, v := range posBins {
 		positions := binaryOnesPositions(v, total)
-		combination := make([]string, 0)
+		combination := make([]T, 0)
 		for _, p := range positions {
 			combination = append(combination, cards[p])
 		}
@@ -131,12 +175,12 @@ func GetPossibleCombinations(cards []string, n int) [][]string {
 	return combinations
 }
 
-func GetAllPossibleCombinations(boardCards []string, holeCards []string, holeCardsCount int) [][]string {
+func GetAllPossibleCombinations[T any](boardCards []T, holeCards []T, holeCardsCount int) [][]T {
 
-	combinations := make([][]string, 0)
+	combinations := make([][]T, 0)
 
 	if holeCardsCount == 0 {
-		allCards := make([]string, 0)
+		allCards := make([]T, 0)
 		allCards = append(allCards, holeCards...)
 		allCards = append(allCards, boardCards...)
 		return GetPossibleCombinations(allCards, 5)
@@ -148,7 +192,7 @@ func GetAllPossibleCombinations(boardCards []string, holeCards []string, holeCar
 	for _, cards := range holeCardCombinations {
 
 		for _, bCards := range boardCardCombinations {
-			allCards := make([]string, 0)
+			allCards := make([]T, 0)
 			allCards = append(allCards, cards...)
 			allCards = append(allCards, bCards...)
 			combinations = append(combinations, allCards)
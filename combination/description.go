@@ -0,0 +1,249 @@
+package combination
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrUnknownLocale = errors.New("combination: unknown locale")
+)
+
+const (
+	LocaleEnglish = "en"
+)
+
+// Locale has templates to describe combinations.
+//
+// Templates of combinations are fmt formats with explicit argument indexes,
+// arguments are names of primary ranks in order, singular then plural:
+// %[1]s and %[2]s are the first rank, %[3]s and %[4]s are the second one.
+type Locale struct {
+	Combinations    map[Combination]string
+	RoyalFlush      string
+	Ranks           map[int]string
+	RanksPlural     map[int]string
+	Kicker          string
+	Kickers         string
+	KickerSeparator string
+	Separator       string
+}
+
+var localeEnglish = &Locale{
+	Combinations: map[Combination]string{
+		CombinationHighCard:      "High Card, %[1]s",
+		CombinationPair:          "Pair, %[2]s",
+		CombinationTwoPair:       "Two Pair, %[2]s and %[4]s",
+		CombinationThreeOfAKind:  "Three of a Kind, %[2]s",
+		CombinationStraight:      "Straight, %[1]s high",
+		CombinationFlush:         "Flush, %[1]s high",
+		CombinationFullHouse:     "Full House, %[2]s full of %[4]s",
+		CombinationFourOfAKind:   "Four of a Kind, %[2]s",
+		CombinationStraightFlush: "Straight Flush, %[1]s high",
+		CombinationFiveOfAKind:   "Five of a Kind, %[2]s",
+	},
+	RoyalFlush: "Royal Flush",
+	Ranks: map[int]string{
+		2:  "Two",
+		3:  "Three",
+		4:  "Four",
+		5:  "Five",
+		6:  "Six",
+		7:  "Seven",
+		8:  "Eight",
+		9:  "Nine",
+		10: "Ten",
+		11: "Jack",
+		12: "Queen",
+		13: "King",
+		14: "Ace",
+	},
+	RanksPlural: map[int]string{
+		2:  "Twos",
+		3:  "Threes",
+		4:  "Fours",
+		5:  "Fives",
+		6:  "Sixes",
+		7:  "Sevens",
+		8:  "Eights",
+		9:  "Nines",
+		10: "Tens",
+		11: "Jacks",
+		12: "Queens",
+		13: "Kings",
+		14: "Aces",
+	},
+	Kicker:          "%s kicker",
+	Kickers:         "%s kickers",
+	KickerSeparator: "-",
+	Separator:       ", ",
+}
+
+var locales = struct {
+	sync.RWMutex
+	locales map[string]*Locale
+}{
+	locales: map[string]*Locale{
+		LocaleEnglish: localeEnglish,
+	},
+}
+
+// RegisterLocale makes a custom locale available for descriptions.
+func RegisterLocale(name string, l *Locale) {
+
+	locales.Lock()
+	defer locales.Unlock()
+
+	locales.locales[name] = l
+}
+
+// GetLocale returns locale by name, English is used if name is empty.
+func GetLocale(name string) (*Locale, error) {
+
+	if len(name) == 0 {
+		name = LocaleEnglish
+	}
+
+	locales.RLock()
+	defer locales.RUnlock()
+
+	l, ok := locales.locales[name]
+	if !ok {
+		return nil, ErrUnknownLocale
+	}
+
+	return l, nil
+}
+
+// Count of primary ranks of combinations, the rest of elements are kickers.
+// Straights have no kicker.
+var primaryRankCount = map[Combination]int{
+	CombinationHighCard:      1,
+	CombinationPair:          1,
+	CombinationTwoPair:       2,
+	CombinationThreeOfAKind:  1,
+	CombinationStraight:      5,
+	CombinationFlush:         1,
+	CombinationFullHouse:     2,
+	CombinationFourOfAKind:   1,
+	CombinationStraightFlush: 5,
+	CombinationFiveOfAKind:   1,
+}
+
+// Description is the structured form of a combination description
+type Description struct {
+	Combination Combination
+	Ranks       []int
+	Kickers     []int
+}
+
+// Describe splits elements of power state into primary ranks and kickers.
+func Describe(ps *PowerState) *Description {
+
+	d := &Description{
+		Combination: ps.Combination,
+		Ranks:       make([]int, 0),
+		Kickers:     make([]int, 0),
+	}
+
+	if len(ps.Elements) == 0 {
+		return d
+	}
+
+	switch ps.Combination {
+	case CombinationStraight:
+		fallthrough
+	case CombinationStraightFlush:
+
+		// A, 5, 4, 3, 2
+		if len(ps.Elements) > 1 && ps.Elements[0].Rank == 14 && ps.Elements[1].Rank == 5 {
+			d.Ranks = append(d.Ranks, 5)
+		} else {
+			d.Ranks = append(d.Ranks, ps.Elements[0].Rank)
+		}
+
+		return d
+	}
+
+	for i, e := range ps.Elements {
+		if i < primaryRankCount[ps.Combination] {
+			d.Ranks = append(d.Ranks, e.Rank)
+		} else {
+			d.Kickers = append(d.Kickers, e.Rank)
+		}
+	}
+
+	return d
+}
+
+// DecidingKicker returns the kicker of winner which beats the other hand.
+// It returns false if combinations or primary ranks are different already.
+func DecidingKicker(winner *PowerState, other *PowerState) (int, bool) {
+
+	w := Describe(winner)
+	o := Describe(other)
+
+	if w.Combination != o.Combination || len(w.Ranks) != len(o.Ranks) {
+		return 0, false
+	}
+
+	for i, r := range w.Ranks {
+		if o.Ranks[i] != r {
+			return 0, false
+		}
+	}
+
+	for i, k := range w.Kickers {
+		if i >= len(o.Kickers) || o.Kickers[i] != k {
+			return k, true
+		}
+	}
+
+	return 0, false
+}
+
+// String returns description in English
+func (d *Description) String() string {
+	return d.Localize(localeEnglish)
+}
+
+// Localize returns description with templates of locale, for instance,
+// "Two Pair, Kings and Sevens, Ace kicker".
+func (d *Description) Localize(l *Locale) string {
+
+	if len(d.Ranks) == 0 {
+		return ""
+	}
+
+	if d.Combination == CombinationStraightFlush && d.Ranks[0] == 14 && len(l.RoyalFlush) > 0 {
+		return l.RoyalFlush
+	}
+
+	args := make([]interface{}, 0, len(d.Ranks)*2)
+	for _, r := range d.Ranks {
+		args = append(args, l.Ranks[r], l.RanksPlural[r])
+	}
+
+	desc := fmt.Sprintf(l.Combinations[d.Combination], args...)
+
+	switch len(d.Kickers) {
+	case 0:
+		return desc
+	case 1:
+		return desc + l.Separator + fmt.Sprintf(l.Kicker, l.Ranks[d.Kickers[0]])
+	}
+
+	kickers := make([]string, 0, len(d.Kickers))
+	for _, k := range d.Kickers {
+		kickers = append(kickers, l.Ranks[k])
+	}
+
+	return desc + l.Separator + fmt.Sprintf(l.Kickers, strings.Join(kickers, l.KickerSeparator))
+}
+
+// LocalizeKicker returns description of a single kicker, for instance, "Ace kicker".
+func (l *Locale) LocalizeKicker(rank int) string {
+	return fmt.Sprintf(l.Kicker, l.Ranks[rank])
+}
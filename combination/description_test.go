@@ -0,0 +1,96 @@
+package combination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokerface/card"
+)
+
+func TestDescribe(t *testing.T) {
+
+	e := NewHighEvaluator(CombinationPowerStandard)
+
+	cases := map[string][]string{
+		"High Card, Ace, King-Nine-Seven-Four kickers":  {"SA", "HK", "D9", "C7", "S4"},
+		"Pair, Queens, Ace-Nine-Two kickers":            {"SQ", "HQ", "DA", "C9", "S2"},
+		"Two Pair, Kings and Sevens, Ace kicker":        {"SK", "HK", "D7", "C7", "SA"},
+		"Three of a Kind, Fives, Jack-Three kickers":    {"S5", "H5", "D5", "CJ", "S3"},
+		"Straight, Five high":                           {"SA", "H2", "D3", "C4", "S5"},
+		"Straight, Ten high":                            {"S6", "H7", "D8", "C9", "ST"},
+		"Flush, Jack high, Nine-Eight-Four-Two kickers": {"SJ", "S9", "S8", "S4", "S2"},
+		"Full House, Threes full of Aces":               {"S3", "H3", "D3", "CA", "SA"},
+		"Four of a Kind, Nines, Six kicker":             {"S9", "H9", "D9", "C9", "S6"},
+		"Straight Flush, Nine high":                     {"H5", "H6", "H7", "H8", "H9"},
+		"Royal Flush":                                   {"HA", "HK", "HQ", "HJ", "HT"},
+		"Pair, Aces":                                    {"SA", "HA"},
+	}
+
+	for expected, cards := range cases {
+		ps := e.CalculatePower(card.MustParseCards(cards...))
+		assert.Equal(t, expected, Describe(ps).String())
+	}
+
+	we := NewWildEvaluator(NewHighEvaluator(CombinationPowerWild), nil)
+	ps := we.CalculatePower(card.MustParseCards("SK", "HK", "DK", "CK", "JR"))
+	assert.Equal(t, "Five of a Kind, Kings", Describe(ps).String())
+}
+
+func TestDecidingKicker(t *testing.T) {
+
+	e := NewHighEvaluator(CombinationPowerStandard)
+
+	winner := e.CalculatePower(card.MustParseCards("SK", "HK", "D7", "C7", "SA"))
+	other := e.CalculatePower(card.MustParseCards("DK", "CK", "H7", "S7", "SQ"))
+
+	kicker, ok := DecidingKicker(winner, other)
+	assert.True(t, ok)
+	assert.Equal(t, 14, kicker)
+
+	l, err := GetLocale("")
+	assert.Nil(t, err)
+	assert.Equal(t, "Ace kicker", l.LocalizeKicker(kicker))
+
+	// Second kicker
+	winner = e.CalculatePower(card.MustParseCards("SQ", "HQ", "DA", "C9", "S3"))
+	other = e.CalculatePower(card.MustParseCards("DQ", "CQ", "HA", "S9", "S2"))
+	kicker, ok = DecidingKicker(winner, other)
+	assert.True(t, ok)
+	assert.Equal(t, 3, kicker)
+
+	// Decided by pair rather than kicker
+	other = e.CalculatePower(card.MustParseCards("DJ", "CJ", "HA", "S9", "S2"))
+	_, ok = DecidingKicker(winner, other)
+	assert.False(t, ok)
+
+	// Different combinations
+	other = e.CalculatePower(card.MustParseCards("DJ", "CT", "HA", "S9", "S2"))
+	_, ok = DecidingKicker(winner, other)
+	assert.False(t, ok)
+}
+
+func TestRegisterLocale(t *testing.T) {
+
+	_, err := GetLocale("zz")
+	assert.Equal(t, ErrUnknownLocale, err)
+
+	l := *localeEnglish
+	l.Combinations = map[Combination]string{
+		CombinationTwoPair: "Deux Paires, %[2]s et %[4]s",
+	}
+	l.RanksPlural = map[int]string{
+		13: "Rois",
+		7:  "Sept",
+	}
+	l.Ranks = map[int]string{
+		14: "As",
+	}
+	l.Kicker = "kicker %s"
+	RegisterLocale("fr", &l)
+
+	fr, err := GetLocale("fr")
+	assert.Nil(t, err)
+
+	ps := NewHighEvaluator(nil).CalculatePower(card.MustParseCards("SK", "HK", "D7", "C7", "SA"))
+	assert.Equal(t, "Deux Paires, Rois et Sept, kicker As", Describe(ps).Localize(fr))
+}
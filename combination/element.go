@@ -25,6 +25,8 @@ func GetElementsByRank(cards []*Card) []*Element {
 				ele.Combination = CombinationThreeOfAKind
 			} else if ele.Count == 4 {
 				ele.Combination = CombinationFourOfAKind
+			} else if ele.Count == 5 {
+				ele.Combination = CombinationFiveOfAKind
 			}
 
 			continue
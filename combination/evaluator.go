@@ -0,0 +1,100 @@
+package combination
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/weedbox/pokerface/card"
+)
+
+var (
+	ErrUnknownEvaluator = errors.New("combination: unknown evaluator")
+)
+
+const (
+	EvaluatorHigh      = "high"
+	EvaluatorLowballA5 = "lowball_a5"
+	EvaluatorLowball27 = "lowball_27"
+)
+
+// Evaluator calculates power of cards, the higher score is always the
+// stronger combination no matter what the rule is, so that settlement can
+// rank players by score directly.
+type Evaluator interface {
+
+	// CalculatePower returns power state of a combination which has 5 cards at most
+	CalculatePower(cards []card.Card) *PowerState
+
+	// Evaluate returns score of the best combination of cards
+	Evaluate(cards []card.Card) uint64
+}
+
+type EvaluatorFactory func(pr PowerRankings) Evaluator
+
+var evaluatorFactories = struct {
+	sync.RWMutex
+	factories map[string]EvaluatorFactory
+}{
+	factories: map[string]EvaluatorFactory{
+		EvaluatorHigh: func(pr PowerRankings) Evaluator {
+			return NewHighEvaluator(pr)
+		},
+		EvaluatorLowballA5: func(pr PowerRankings) Evaluator {
+			return NewLowballA5Evaluator(pr)
+		},
+		EvaluatorLowball27: func(pr PowerRankings) Evaluator {
+			return NewLowball27Evaluator(pr)
+		},
+	},
+}
+
+// RegisterEvaluator makes a custom evaluator available for games.
+func RegisterEvaluator(name string, factory EvaluatorFactory) {
+
+	evaluatorFactories.Lock()
+	defer evaluatorFactories.Unlock()
+
+	evaluatorFactories.factories[name] = factory
+}
+
+// NewEvaluator creates evaluator by name, the high evaluator is used if name is empty.
+func NewEvaluator(name string, pr PowerRankings) (Evaluator, error) {
+
+	if len(name) == 0 {
+		name = EvaluatorHigh
+	}
+
+	evaluatorFactories.RLock()
+	factory, ok := evaluatorFactories.factories[name]
+	evaluatorFactories.RUnlock()
+
+	if !ok {
+		return nil, ErrUnknownEvaluator
+	}
+
+	return factory(pr), nil
+}
+
+// HighEvaluator is the original evaluator that the higher combination wins.
+type HighEvaluator struct {
+	rankings PowerRankings
+}
+
+func NewHighEvaluator(pr PowerRankings) *HighEvaluator {
+
+	if len(pr) == 0 {
+		pr = CombinationPowerStandard
+	}
+
+	return &HighEvaluator{
+		rankings: pr,
+	}
+}
+
+func (he *HighEvaluator) CalculatePower(cards []card.Card) *PowerState {
+	return CalculatePower(he.rankings, card.Strings(cards))
+}
+
+func (he *HighEvaluator) Evaluate(cards []card.Card) uint64 {
+	return GetLookupEvaluator(he.rankings).Evaluate(cards)
+}
@@ -0,0 +1,51 @@
+package combination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokerface/card"
+)
+
+func TestNewEvaluator(t *testing.T) {
+
+	e, err := NewEvaluator("", CombinationPowerStandard)
+	assert.Nil(t, err)
+	assert.IsType(t, &HighEvaluator{}, e)
+
+	e, err = NewEvaluator(EvaluatorLowballA5, nil)
+	assert.Nil(t, err)
+	assert.IsType(t, &LowballEvaluator{}, e)
+
+	_, err = NewEvaluator("unknown", nil)
+	assert.Equal(t, ErrUnknownEvaluator, err)
+}
+
+func TestHighEvaluator_SameAsCalculatePower(t *testing.T) {
+
+	e := NewHighEvaluator(CombinationPowerShortDeck)
+	cards := []string{"S6", "S7", "S8", "S9", "ST", "HT", "DT"}
+
+	ps := e.CalculatePower(card.MustParseCards(cards[:5]...))
+	assert.Equal(t, CalculatePower(CombinationPowerShortDeck, cards[:5]).Score, ps.Score)
+	assert.Equal(t, ps.Score, e.Evaluate(card.MustParseCards(cards...)))
+}
+
+type reversedEvaluator struct {
+	*HighEvaluator
+}
+
+func (re *reversedEvaluator) Evaluate(cards []card.Card) uint64 {
+	return ^re.HighEvaluator.Evaluate(cards)
+}
+
+func TestRegisterEvaluator(t *testing.T) {
+
+	RegisterEvaluator("reversed", func(pr PowerRankings) Evaluator {
+		return &reversedEvaluator{NewHighEvaluator(pr)}
+	})
+
+	e, err := NewEvaluator("reversed", nil)
+	assert.Nil(t, err)
+	assert.IsType(t, &reversedEvaluator{}, e)
+}
@@ -0,0 +1,315 @@
+package combination
+
+import (
+	"fmt"
+	"math/bits"
+	"sync"
+
+	"github.com/weedbox/pokerface/card"
+)
+
+// LookupEvaluator calculates power scores by precomputed tables instead of
+// parsing and sorting cards for every combination. Scores are exactly the same
+// as the best score given by CalculatePower with the same power rankings.
+//
+// Ranks of cards are encoded as a quinary number (13 digits, one digit for
+// the count of each rank) which is turned into a perfect hash, so that 5, 6
+// and 7 cards can be evaluated with a single table lookup. Flushes are looked
+// up by the rank bitmask of each suit.
+//
+// Note that flushes must be stronger than high card and straight in the
+// power rankings, which is true for both standard and short deck rankings.
+type LookupEvaluator struct {
+	rankings PowerRankings
+	flush    []uint32
+	ranks    [3][]uint32
+}
+
+const (
+	lookupRankCount    = 13
+	lookupMinCardCount = 5
+	lookupMaxCardCount = 7
+)
+
+var (
+	// quinaryCounts[n][k] is the number of n-digit quinary numbers which digit sum is k
+	quinaryCounts [lookupRankCount + 1][lookupMaxCardCount + 1]int
+
+	// quinaryOffsets[d][n][k] is the number of n-digit quinary numbers which are skipped by digit d
+	quinaryOffsets [5][lookupRankCount][lookupMaxCardCount + 1]int
+)
+
+var lookupEvaluators = struct {
+	sync.Mutex
+	evaluators map[string]*LookupEvaluator
+}{
+	evaluators: make(map[string]*LookupEvaluator),
+}
+
+func init() {
+
+	quinaryCounts[0][0] = 1
+	for n := 1; n <= lookupRankCount; n++ {
+		for k := 0; k <= lookupMaxCardCount; k++ {
+			for d := 0; d <= 4 && d <= k; d++ {
+				quinaryCounts[n][k] += quinaryCounts[n-1][k-d]
+			}
+		}
+	}
+
+	for d := 1; d < 5; d++ {
+		for n := 0; n < lookupRankCount; n++ {
+			for k := 0; k <= lookupMaxCardCount; k++ {
+				quinaryOffsets[d][n][k] = quinaryOffsets[d-1][n][k]
+				if k >= d-1 {
+					quinaryOffsets[d][n][k] += quinaryCounts[n][k-d+1]
+				}
+			}
+		}
+	}
+}
+
+// GetLookupEvaluator returns a shared evaluator for specific power rankings,
+// tables will be built at the first time.
+func GetLookupEvaluator(pr PowerRankings) *LookupEvaluator {
+
+	key := fmt.Sprint([]Combination(pr))
+
+	lookupEvaluators.Lock()
+	defer lookupEvaluators.Unlock()
+
+	le, ok := lookupEvaluators.evaluators[key]
+	if ok {
+		return le
+	}
+
+	le = NewLookupEvaluator(pr)
+	lookupEvaluators.evaluators[key] = le
+
+	return le
+}
+
+func NewLookupEvaluator(pr PowerRankings) *LookupEvaluator {
+
+	le := &LookupEvaluator{
+		rankings: pr,
+	}
+
+	le.buildRankTables()
+	le.buildFlushTable()
+
+	return le
+}
+
+func hashQuinary(quinary *[lookupRankCount]uint8, k int) int {
+
+	sum := 0
+	for i := 0; i < lookupRankCount && k > 0; i++ {
+		d := quinary[i]
+		sum += quinaryOffsets[d][lookupRankCount-i-1][k]
+		k -= int(d)
+	}
+
+	return sum
+}
+
+func walkQuinary(quinary *[lookupRankCount]uint8, pos int, k int, fn func()) {
+
+	if pos == lookupRankCount {
+		if k == 0 {
+			fn()
+		}
+		return
+	}
+
+	for d := 0; d <= 4 && d <= k; d++ {
+		quinary[pos] = uint8(d)
+		walkQuinary(quinary, pos+1, k-d, fn)
+	}
+
+	quinary[pos] = 0
+}
+
+func (le *LookupEvaluator) buildRankTables() {
+
+	var quinary [lookupRankCount]uint8
+
+	for n := lookupMinCardCount; n <= lookupMaxCardCount; n++ {
+
+		table := make([]uint32, quinaryCounts[lookupRankCount][n])
+
+		walkQuinary(&quinary, 0, n, func() {
+
+			idx := hashQuinary(&quinary, n)
+
+			if n == lookupMinCardCount {
+
+				// Suits are assigned in rotation so cards never be a flush
+				cards := make([]string, 0, n)
+				for r, count := range quinary {
+					for i := 0; i < int(count); i++ {
+						cards = append(cards, fmt.Sprintf("%s%s", SuitSymbol[len(cards)%4+1], CardSymbol[r+2]))
+					}
+				}
+
+				table[idx] = uint32(CalculatePower(le.rankings, cards).Score)
+				return
+			}
+
+			// The best combination without one of cards
+			prev := le.ranks[n-lookupMinCardCount-1]
+			for r := range quinary {
+
+				if quinary[r] == 0 {
+					continue
+				}
+
+				quinary[r]--
+				score := prev[hashQuinary(&quinary, n-1)]
+				quinary[r]++
+
+				if score > table[idx] {
+					table[idx] = score
+				}
+			}
+		})
+
+		le.ranks[n-lookupMinCardCount] = table
+	}
+}
+
+func (le *LookupEvaluator) buildFlushTable() {
+
+	le.flush = make([]uint32, 1<<lookupRankCount)
+
+	// Sub-masks are always less than the mask so the table can be built in order
+	for mask := 0; mask < len(le.flush); mask++ {
+
+		count := bits.OnesCount(uint(mask))
+		if count < lookupMinCardCount {
+			continue
+		}
+
+		if count == lookupMinCardCount {
+
+			cards := make([]string, 0, count)
+			for r := 0; r < lookupRankCount; r++ {
+				if mask&(1<<r) != 0 {
+					cards = append(cards, fmt.Sprintf("%s%s", SuitSymbol[1], CardSymbol[r+2]))
+				}
+			}
+
+			le.flush[mask] = uint32(CalculatePower(le.rankings, cards).Score)
+			continue
+		}
+
+		for r := 0; r < lookupRankCount; r++ {
+
+			if mask&(1<<r) == 0 {
+				continue
+			}
+
+			score := le.flush[mask&^(1<<r)]
+			if score > le.flush[mask] {
+				le.flush[mask] = score
+			}
+		}
+	}
+}
+
+// Evaluate returns score of the best combination of cards. 5, 6 and 7 cards
+// are evaluated by tables directly, other amounts fall back to the original
+// way. Jokers have no rank, they are substituted as wild cards.
+func (le *LookupEvaluator) Evaluate(cards []card.Card) uint64 {
+
+	for _, c := range cards {
+		if c.IsJoker() {
+			return le.evaluateWild(cards)
+		}
+	}
+
+	n := len(cards)
+	if n < lookupMinCardCount {
+		return le.evaluatePartial(cards)
+	} else if n > lookupMaxCardCount {
+		return le.evaluateCombinations(cards)
+	}
+
+	var quinary [lookupRankCount]uint8
+	var suits [4]uint16
+
+	for _, c := range cards {
+		r := c.Rank() - card.MinRank
+		quinary[r]++
+		suits[c.Suit()] |= 1 << r
+	}
+
+	return le.evaluate(&quinary, &suits, n)
+}
+
+// EvaluateSet returns score of the best combination of cards in set.
+func (le *LookupEvaluator) EvaluateSet(cs card.CardSet) uint64 {
+
+	n := cs.Count()
+	if n < lookupMinCardCount || n > lookupMaxCardCount || cs.Contains(card.JokerBlack) || cs.Contains(card.JokerRed) {
+		return le.Evaluate(cs.Cards())
+	}
+
+	var quinary [lookupRankCount]uint8
+	var suits [4]uint16
+
+	for _, s := range card.Suits {
+		suits[s] = cs.RankMask(s)
+		for m := suits[s]; m != 0; m &= m - 1 {
+			quinary[bits.TrailingZeros16(m)]++
+		}
+	}
+
+	return le.evaluate(&quinary, &suits, n)
+}
+
+// EvaluateSymbols returns score of the best combination of cards in string notation.
+func (le *LookupEvaluator) EvaluateSymbols(cards []string) (uint64, error) {
+
+	cs, err := card.ParseCards(cards)
+	if err != nil {
+		return 0, err
+	}
+
+	return le.Evaluate(cs), nil
+}
+
+func (le *LookupEvaluator) evaluate(quinary *[lookupRankCount]uint8, suits *[4]uint16, n int) uint64 {
+
+	score := le.ranks[n-lookupMinCardCount][hashQuinary(quinary, n)]
+
+	for _, mask := range suits {
+		if s := le.flush[mask]; s > score {
+			score = s
+		}
+	}
+
+	return uint64(score)
+}
+
+func (le *LookupEvaluator) evaluateWild(cards []card.Card) uint64 {
+	return NewWildEvaluator(NewHighEvaluator(le.rankings), nil).Evaluate(cards)
+}
+
+func (le *LookupEvaluator) evaluatePartial(cards []card.Card) uint64 {
+	return CalculatePower(le.rankings, card.Strings(cards)).Score
+}
+
+func (le *LookupEvaluator) evaluateCombinations(cards []card.Card) uint64 {
+
+	best := uint64(0)
+	for _, c := range GetPossibleCombinations(cards, lookupMinCardCount) {
+
+		score := le.Evaluate(c)
+		if score > best {
+			best = score
+		}
+	}
+
+	return best
+}
@@ -0,0 +1,222 @@
+package combination
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokerface/card"
+)
+
+func newTestDeck(minRank int) []string {
+
+	cards := make([]string, 0, 52)
+	for s := 1; s <= 4; s++ {
+		for r := minRank; r <= 14; r++ {
+			cards = append(cards, SuitSymbol[s]+CardSymbol[r])
+		}
+	}
+
+	return cards
+}
+
+func calculateBestPower(pr PowerRankings, cards []string) uint64 {
+
+	best := uint64(0)
+	for _, c := range GetPossibleCombinations(cards, 5) {
+		ps := CalculatePower(pr, c)
+		if ps.Score > best {
+			best = ps.Score
+		}
+	}
+
+	return best
+}
+
+func TestLookupEvaluator_SameAsCalculatePower(t *testing.T) {
+
+	rankings := map[string]PowerRankings{
+		"standard":   CombinationPowerStandard,
+		"short_deck": CombinationPowerShortDeck,
+	}
+
+	decks := map[string][]string{
+		"standard":   newTestDeck(2),
+		"short_deck": newTestDeck(6),
+	}
+
+	r := rand.New(rand.NewSource(1))
+
+	for name, pr := range rankings {
+
+		le := NewLookupEvaluator(pr)
+
+		for _, deck := range decks {
+			for count := 2; count <= 8; count++ {
+				for i := 0; i < 1000; i++ {
+
+					r.Shuffle(len(deck), func(i, j int) {
+						deck[i], deck[j] = deck[j], deck[i]
+					})
+
+					cards := deck[:count]
+
+					score, err := le.EvaluateSymbols(cards)
+					assert.Nil(t, err)
+
+					if !assert.Equal(t, calculateBestPower(pr, cards), score, name, cards) {
+						return
+					}
+
+					assert.Equal(t, score, le.EvaluateSet(card.NewCardSet(card.MustParseCards(cards...)...)))
+				}
+			}
+		}
+	}
+}
+
+func TestLookupEvaluator_StraightFlush(t *testing.T) {
+
+	le := GetLookupEvaluator(CombinationPowerStandard)
+
+	// Straight flush hidden in 7 cards with another straight
+	score, err := le.EvaluateSymbols([]string{"S5", "S6", "S7", "S8", "S9", "HT", "DJ"})
+	assert.Nil(t, err)
+	assert.Equal(t, CalculatePower(CombinationPowerStandard, []string{"S5", "S6", "S7", "S8", "S9"}).Score, score)
+
+	// Wheel
+	score, err = le.EvaluateSymbols([]string{"HA", "S2", "D3", "C4", "S5", "SK", "HK"})
+	assert.Nil(t, err)
+	assert.Equal(t, CalculatePower(CombinationPowerStandard, []string{"HA", "S2", "D3", "C4", "S5"}).Score, score)
+}
+
+func TestLookupEvaluator_Joker(t *testing.T) {
+
+	le := GetLookupEvaluator(CombinationPowerStandard)
+	we := NewWildEvaluator(NewHighEvaluator(CombinationPowerStandard), nil)
+
+	// Joker is substituted as wild card instead of being looked up by rank
+	hands := [][]string{
+		{"JB", "S5", "S6", "S7", "S8"},
+		{"JB", "JR", "HA", "DA", "C2", "S9", "HT"},
+		{"JR", "SK", "HQ"},
+	}
+
+	for _, h := range hands {
+
+		score, err := le.EvaluateSymbols(h)
+		assert.Nil(t, err, h)
+		assert.Equal(t, we.Evaluate(card.MustParseCards(h...)), score, h)
+		assert.Equal(t, score, le.EvaluateSet(card.NewCardSet(card.MustParseCards(h...)...)), h)
+	}
+
+	// Straight flush with joker
+	score, err := le.EvaluateSymbols([]string{"JB", "S5", "S6", "S7", "S8"})
+	assert.Nil(t, err)
+	assert.Equal(t, CalculatePower(CombinationPowerStandard, []string{"S5", "S6", "S7", "S8", "S9"}).Score, score)
+}
+
+func TestGetLookupEvaluator_Shared(t *testing.T) {
+	assert.Same(t, GetLookupEvaluator(CombinationPowerStandard), GetLookupEvaluator(CombinationPowerStandard))
+	assert.NotSame(t, GetLookupEvaluator(CombinationPowerStandard), GetLookupEvaluator(CombinationPowerShortDeck))
+}
+
+var benchmarkHands = func() [][]string {
+
+	deck := newTestDeck(2)
+	r := rand.New(rand.NewSource(1))
+
+	hands := make([][]string, 0, 1024)
+	for i := 0; i < 1024; i++ {
+
+		r.Shuffle(len(deck), func(i, j int) {
+			deck[i], deck[j] = deck[j], deck[i]
+		})
+
+		hand := make([]string, 7)
+		copy(hand, deck[:7])
+		hands = append(hands, hand)
+	}
+
+	return hands
+}()
+
+func BenchmarkCalculatePower_5Cards(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		CalculatePower(CombinationPowerStandard, benchmarkHands[i%len(benchmarkHands)][:5])
+	}
+}
+
+func BenchmarkLookupEvaluator_5Cards(b *testing.B) {
+
+	le := GetLookupEvaluator(CombinationPowerStandard)
+
+	hands := make([][]card.Card, 0, len(benchmarkHands))
+	for _, h := range benchmarkHands {
+		codes := card.MustParseCards(h[:5]...)
+		hands = append(hands, codes)
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		le.Evaluate(hands[i%len(hands)])
+	}
+}
+
+func BenchmarkCalculatePower_7Cards(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		h := benchmarkHands[i%len(benchmarkHands)]
+		for _, c := range GetAllPossibleCombinations(h[2:], h[:2], 0) {
+			CalculatePower(CombinationPowerStandard, c)
+		}
+	}
+}
+
+func BenchmarkLookupEvaluator_7Cards(b *testing.B) {
+
+	le := GetLookupEvaluator(CombinationPowerStandard)
+
+	hands := make([][]card.Card, 0, len(benchmarkHands))
+	for _, h := range benchmarkHands {
+		codes := card.MustParseCards(h...)
+		hands = append(hands, codes)
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		le.Evaluate(hands[i%len(hands)])
+	}
+}
+
+func BenchmarkLookupEvaluator_Symbols7Cards(b *testing.B) {
+
+	le := GetLookupEvaluator(CombinationPowerStandard)
+
+	for i := 0; i < b.N; i++ {
+		le.EvaluateSymbols(benchmarkHands[i%len(benchmarkHands)])
+	}
+}
+
+func BenchmarkNewLookupEvaluator(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		NewLookupEvaluator(CombinationPowerStandard)
+	}
+}
+
+func BenchmarkLookupEvaluator_7CardSet(b *testing.B) {
+
+	le := GetLookupEvaluator(CombinationPowerStandard)
+
+	sets := make([]card.CardSet, 0, len(benchmarkHands))
+	for _, h := range benchmarkHands {
+		sets = append(sets, card.NewCardSet(card.MustParseCards(h...)...))
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		le.EvaluateSet(sets[i%len(sets)])
+	}
+}
@@ -0,0 +1,159 @@
+package combination
+
+import (
+	"sort"
+
+	"github.com/weedbox/pokerface/card"
+)
+
+// LowballEvaluator calculates power for lowball games. Scores are inverted
+// so that the lowest hand gets the highest score.
+//
+// A-5: aces are low, straights and flushes are ignored, so 5-4-3-2-A wins.
+// 2-7: aces are high only, straights and flushes count, so 7-5-4-3-2 wins.
+type LowballEvaluator struct {
+	rankings  PowerRankings
+	aceLow    bool
+	withSuits bool
+}
+
+func NewLowballA5Evaluator(pr PowerRankings) *LowballEvaluator {
+
+	if len(pr) == 0 {
+		pr = CombinationPowerLowballA5
+	}
+
+	return &LowballEvaluator{
+		rankings:  pr,
+		aceLow:    true,
+		withSuits: false,
+	}
+}
+
+func NewLowball27Evaluator(pr PowerRankings) *LowballEvaluator {
+
+	if len(pr) == 0 {
+		pr = CombinationPowerLowball27
+	}
+
+	return &LowballEvaluator{
+		rankings:  pr,
+		aceLow:    false,
+		withSuits: true,
+	}
+}
+
+func (le *LowballEvaluator) value(rank int) int {
+
+	if le.aceLow && rank == 14 {
+		return 1
+	}
+
+	return rank
+}
+
+func (le *LowballEvaluator) CalculatePower(cards []card.Card) *PowerState {
+
+	states := make([]*Card, 0, len(cards))
+	for _, c := range cards {
+		states = append(states, NewCardState(c))
+	}
+
+	// Sorting based on value of rank
+	sort.Slice(states, func(i, j int) bool {
+		return le.value(states[i].Rank) > le.value(states[j].Rank)
+	})
+
+	ps := &PowerState{
+		Cards:       states,
+		Combination: CombinationHighCard,
+		Elements:    GetElementsByRank(states),
+	}
+
+	if le.withSuits {
+
+		if len(states) == 5 && isFlush(states) {
+			ps.Combination = CombinationFlush
+		}
+
+		if le.isStraight(ps.Elements) {
+			if ps.Combination == CombinationFlush {
+				ps.Combination = CombinationStraightFlush
+			} else {
+				ps.Combination = CombinationStraight
+			}
+		}
+	}
+
+	if isFiveOfAKind(ps.Elements) {
+		ps.Combination = CombinationFiveOfAKind
+	} else if isFourOfAKind(ps.Elements) {
+		ps.Combination = CombinationFourOfAKind
+	} else if isFullHouse(ps.Elements) {
+		ps.Combination = CombinationFullHouse
+	} else if isThreeOfAKind(ps.Elements) {
+		ps.Combination = CombinationThreeOfAKind
+	} else if isTwoPair(ps.Elements) {
+		ps.Combination = CombinationTwoPair
+	} else if isPair(ps.Elements) {
+		ps.Combination = CombinationPair
+	}
+
+	// The lower raw score the better
+	ps.Score = CalculatePowerLevels(le.rankings, ps) + CombinationLevel[ps.Combination] - 1 - le.calculateRawScore(ps)
+
+	return ps
+}
+
+func (le *LowballEvaluator) isStraight(elements []*Element) bool {
+
+	// Ace is always high so there is no wheel
+	if len(elements) != 5 {
+		return false
+	}
+
+	for i := 1; i < len(elements); i++ {
+		if le.value(elements[i-1].Rank)-le.value(elements[i].Rank) != 1 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (le *LowballEvaluator) calculateRawScore(ps *PowerState) uint64 {
+
+	switch ps.Combination {
+	case CombinationStraight:
+		fallthrough
+	case CombinationStraightFlush:
+		// 6-high straight is the lowest one
+		return uint64(le.value(ps.Elements[0].Rank) - 6)
+	}
+
+	minValue := 2
+	if le.aceLow {
+		minValue = 1
+	}
+
+	score := uint64(0)
+	for _, e := range ps.Elements {
+		score = score*13 + uint64(le.value(e.Rank)-minValue)
+	}
+
+	return score
+}
+
+func (le *LowballEvaluator) Evaluate(cards []card.Card) uint64 {
+
+	best := uint64(0)
+	for _, c := range GetPossibleCombinations(cards, 5) {
+
+		score := le.CalculatePower(c).Score
+		if score > best {
+			best = score
+		}
+	}
+
+	return best
+}
@@ -0,0 +1,110 @@
+package combination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokerface/card"
+)
+
+func TestLowballA5Evaluator_Ordering(t *testing.T) {
+
+	// From the worst to the best
+	cardSets := [][]string{
+		// FourOfAKind
+		{"SK", "HK", "DK", "CK", "SQ"},
+		{"S2", "H2", "D2", "C2", "S3"},
+		// FullHouse
+		{"SK", "HK", "DK", "CQ", "SQ"},
+		{"S2", "H2", "D2", "CA", "SA"},
+		// ThreeOfAKind
+		{"SK", "HK", "DK", "C4", "S3"},
+		// TwoPair
+		{"SK", "HK", "D3", "C3", "S2"},
+		{"S3", "H3", "D2", "C2", "SA"},
+		// Pair
+		{"SK", "HK", "D4", "C3", "S2"},
+		{"SA", "HA", "D4", "C3", "S2"},
+		// HighCard
+		{"SK", "HQ", "DJ", "CT", "S9"},
+		{"S8", "H7", "D4", "C3", "S2"},
+		{"S7", "H6", "D4", "C3", "S2"},
+		{"S6", "H4", "D3", "C2", "SA"},
+		{"S5", "H4", "D3", "C2", "SA"},
+	}
+
+	le := NewLowballA5Evaluator(nil)
+
+	prevScore := uint64(0)
+	for _, cardSymbols := range cardSets {
+		ps := le.CalculatePower(card.MustParseCards(cardSymbols...))
+		assert.Greater(t, ps.Score, prevScore, cardSymbols)
+		prevScore = ps.Score
+	}
+}
+
+func TestLowballA5Evaluator_IgnoreStraightAndFlush(t *testing.T) {
+
+	le := NewLowballA5Evaluator(nil)
+
+	wheel := le.CalculatePower(card.MustParseCards("S5", "S4", "S3", "S2", "SA"))
+	assert.Equal(t, CombinationHighCard, wheel.Combination)
+	assert.Equal(t, le.CalculatePower(card.MustParseCards("S5", "H4", "D3", "C2", "SA")).Score, wheel.Score)
+
+	// Cards keep the original ranks
+	assert.Equal(t, "SA", wheel.Cards[4].ToString())
+}
+
+func TestLowball27Evaluator_Ordering(t *testing.T) {
+
+	// From the worst to the best
+	cardSets := [][]string{
+		// StraightFlush
+		{"SA", "SK", "SQ", "SJ", "ST"},
+		{"S6", "S5", "S4", "S3", "S2"},
+		// FourOfAKind
+		{"S2", "H2", "D2", "C2", "S3"},
+		// FullHouse
+		{"S2", "H2", "D2", "C3", "S3"},
+		// Flush
+		{"S7", "S5", "S4", "S3", "S2"},
+		// Straight
+		{"SA", "HK", "DQ", "CJ", "ST"},
+		{"S6", "H5", "D4", "C3", "S2"},
+		// ThreeOfAKind
+		{"S2", "H2", "D2", "C4", "S3"},
+		// TwoPair
+		{"S3", "H3", "D2", "C2", "S4"},
+		// Pair
+		{"SA", "HA", "DK", "CQ", "SJ"},
+		{"S2", "H2", "D5", "C4", "S3"},
+		// HighCard
+		{"SA", "H5", "D4", "C3", "S2"},
+		{"SK", "HQ", "DJ", "CT", "S8"},
+		{"S8", "H7", "D4", "C3", "S2"},
+		{"S7", "H6", "D4", "C3", "S2"},
+		{"S7", "H5", "D4", "C3", "S2"},
+	}
+
+	le := NewLowball27Evaluator(nil)
+
+	prevScore := uint64(0)
+	for _, cardSymbols := range cardSets {
+		ps := le.CalculatePower(card.MustParseCards(cardSymbols...))
+		assert.Greater(t, ps.Score, prevScore, cardSymbols)
+		prevScore = ps.Score
+	}
+
+	// A-2-3-4-5 is not a straight
+	ps := le.CalculatePower(card.MustParseCards("SA", "H5", "D4", "C3", "S2"))
+	assert.Equal(t, CombinationHighCard, ps.Combination)
+}
+
+func TestLowballEvaluator_Evaluate(t *testing.T) {
+
+	le := NewLowball27Evaluator(nil)
+
+	cards := card.MustParseCards("S7", "S5", "S4", "S3", "S2", "HK", "DK")
+	best := le.CalculatePower(card.MustParseCards("HK", "S5", "S4", "S3", "S2"))
+	assert.Equal(t, best.Score, le.Evaluate(cards))
+}
@@ -48,7 +48,9 @@ func CalculatePower(pr PowerRankings, cardSymbols []string) *PowerState {
 	}
 
 	// Other combinations
-	if isFourOfAKind(ps.Elements) {
+	if isFiveOfAKind(ps.Elements) {
+		ps.Combination = CombinationFiveOfAKind
+	} else if isFourOfAKind(ps.Elements) {
 		ps.Combination = CombinationFourOfAKind
 	} else if isFullHouse(ps.Elements) {
 		ps.Combination = CombinationFullHouse
@@ -173,6 +175,17 @@ func isStraight(cards []*Card) bool {
 	return true
 }
 
+func isFiveOfAKind(elements []*Element) bool {
+
+	for _, ele := range elements {
+		if ele.Count == 5 {
+			return true
+		}
+	}
+
+	return false
+}
+
 func isFourOfAKind(elements []*Element) bool {
 
 	for _, ele := range elements {
@@ -0,0 +1,466 @@
+package combination
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/weedbox/pokerface/card"
+)
+
+var (
+	ErrInvalidRange = errors.New("combination: invalid range")
+)
+
+const (
+	suitednessAny = iota
+	suitednessSuited
+	suitednessOffsuit
+)
+
+// RangeCombo is a concrete combination of hole cards in range
+type RangeCombo struct {
+	Cards  []card.Card `json:"cards"`
+	Weight float64     `json:"weight"`
+}
+
+// Range is a set of hole cards combinations which is parsed from standard
+// range notation (e.g. "TT+, AKs, A5s-A2s, KQo, 76s+").
+//
+// Supported notations:
+//
+//	TT, AKs, KQo, AK  hand classes (AK includes both suited and offsuit)
+//	TT+               pairs from TT to AA
+//	ATs+              raises kicker up to AKs
+//	76s+              connectors are raised together (76s, 87s ... AKs)
+//	TT-77, A5s-A2s    ranges between two hand classes
+//	SAHK              specific hole cards in suit-first symbols
+//	AKs:0.5           weight of combinations, 1 by default
+type Range struct {
+	minRank int
+	combos  []*RangeCombo
+	index   map[card.CardSet]*RangeCombo
+}
+
+type handClass struct {
+	high       int
+	low        int
+	suitedness int
+}
+
+func NewRange(minRank int) *Range {
+	return &Range{
+		minRank: minRank,
+		combos:  make([]*RangeCombo, 0),
+		index:   make(map[card.CardSet]*RangeCombo),
+	}
+}
+
+// ParseRange parses range for standard deck
+func ParseRange(notation string) (*Range, error) {
+	return ParseRangeWithMinRank(notation, card.MinRank)
+}
+
+// ParseShortDeckRange parses range for short deck which has no 2, 3, 4 and 5
+func ParseShortDeckRange(notation string) (*Range, error) {
+	return ParseRangeWithMinRank(notation, 6)
+}
+
+func ParseRangeWithMinRank(notation string, minRank int) (*Range, error) {
+
+	r := NewRange(minRank)
+
+	for _, token := range strings.Split(notation, ",") {
+
+		token = strings.TrimSpace(token)
+		if len(token) == 0 {
+			continue
+		}
+
+		err := r.parseToken(token)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", err, token)
+		}
+	}
+
+	return r, nil
+}
+
+// CountCombos returns the number of combinations of range
+func CountCombos(notation string) (int, error) {
+
+	r, err := ParseRange(notation)
+	if err != nil {
+		return 0, err
+	}
+
+	return r.Count(), nil
+}
+
+func (r *Range) parseToken(token string) error {
+
+	weight := 1.0
+
+	// Weight
+	if i := strings.Index(token, ":"); i >= 0 {
+
+		w, err := strconv.ParseFloat(token[i+1:], 64)
+		if err != nil || w < 0 || w > 1 {
+			return ErrInvalidRange
+		}
+
+		weight = w
+		token = token[:i]
+	}
+
+	if len(token) == 0 {
+		return ErrInvalidRange
+	}
+
+	// Specific hole cards
+	if _, ok := card.SuitBySymbol[token[0:1]]; ok {
+
+		if len(token) != 4 {
+			return ErrInvalidRange
+		}
+
+		cards, err := card.ParseCards([]string{token[0:2], token[2:4]})
+		if err != nil || cards[0] == cards[1] {
+			return ErrInvalidRange
+		}
+
+		if cards[0].Rank() < r.minRank || cards[1].Rank() < r.minRank {
+			return ErrInvalidRange
+		}
+
+		r.Add(cards, weight)
+
+		return nil
+	}
+
+	classes, err := r.parseClasses(token)
+	if err != nil {
+		return err
+	}
+
+	for _, hc := range classes {
+		for _, cards := range r.expandClass(hc) {
+			r.Add(cards, weight)
+		}
+	}
+
+	return nil
+}
+
+func (r *Range) parseClass(token string) (*handClass, error) {
+
+	if len(token) < 2 || len(token) > 3 {
+		return nil, ErrInvalidRange
+	}
+
+	high, ok := card.RankBySymbol[token[0:1]]
+	if !ok {
+		return nil, ErrInvalidRange
+	}
+
+	low, ok := card.RankBySymbol[token[1:2]]
+	if !ok {
+		return nil, ErrInvalidRange
+	}
+
+	if high < low {
+		high, low = low, high
+	}
+
+	if low < r.minRank {
+		return nil, ErrInvalidRange
+	}
+
+	hc := &handClass{
+		high:       high,
+		low:        low,
+		suitedness: suitednessAny,
+	}
+
+	if len(token) == 3 {
+
+		switch token[2:3] {
+		case "s":
+			hc.suitedness = suitednessSuited
+		case "o":
+			hc.suitedness = suitednessOffsuit
+		default:
+			return nil, ErrInvalidRange
+		}
+
+		// Pair is never suited or offsuit
+		if high == low {
+			return nil, ErrInvalidRange
+		}
+	}
+
+	return hc, nil
+}
+
+func (r *Range) parseClasses(token string) ([]*handClass, error) {
+
+	// Between two hand classes
+	if i := strings.Index(token, "-"); i >= 0 {
+
+		from, err := r.parseClass(token[:i])
+		if err != nil {
+			return nil, err
+		}
+
+		to, err := r.parseClass(token[i+1:])
+		if err != nil {
+			return nil, err
+		}
+
+		return r.betweenClasses(from, to)
+	}
+
+	if !strings.HasSuffix(token, "+") {
+
+		hc, err := r.parseClass(token)
+		if err != nil {
+			return nil, err
+		}
+
+		return []*handClass{hc}, nil
+	}
+
+	hc, err := r.parseClass(strings.TrimSuffix(token, "+"))
+	if err != nil {
+		return nil, err
+	}
+
+	classes := make([]*handClass, 0)
+
+	switch {
+	case hc.high == hc.low:
+
+		// Pairs
+		for rank := hc.low; rank <= card.MaxRank; rank++ {
+			classes = append(classes, &handClass{high: rank, low: rank})
+		}
+
+	case hc.high-hc.low == 1:
+
+		// Connectors
+		for high := hc.high; high <= card.MaxRank; high++ {
+			classes = append(classes, &handClass{high: high, low: high - 1, suitedness: hc.suitedness})
+		}
+
+	default:
+
+		// Kickers
+		for low := hc.low; low < hc.high; low++ {
+			classes = append(classes, &handClass{high: hc.high, low: low, suitedness: hc.suitedness})
+		}
+	}
+
+	return classes, nil
+}
+
+func (r *Range) betweenClasses(from *handClass, to *handClass) ([]*handClass, error) {
+
+	if from.suitedness != to.suitedness {
+		return nil, ErrInvalidRange
+	}
+
+	if from.high < to.high || (from.high == to.high && from.low < to.low) {
+		from, to = to, from
+	}
+
+	classes := make([]*handClass, 0)
+
+	switch {
+	case from.high == from.low && to.high == to.low:
+
+		// Pairs
+		for rank := to.low; rank <= from.low; rank++ {
+			classes = append(classes, &handClass{high: rank, low: rank})
+		}
+
+	case from.high == to.high:
+
+		// Kickers
+		for low := to.low; low <= from.low; low++ {
+			classes = append(classes, &handClass{high: from.high, low: low, suitedness: from.suitedness})
+		}
+
+	case from.high-from.low == to.high-to.low:
+
+		// Same gap
+		gap := from.high - from.low
+		for high := to.high; high <= from.high; high++ {
+			classes = append(classes, &handClass{high: high, low: high - gap, suitedness: from.suitedness})
+		}
+
+	default:
+		return nil, ErrInvalidRange
+	}
+
+	return classes, nil
+}
+
+func (r *Range) expandClass(hc *handClass) [][]card.Card {
+
+	combos := make([][]card.Card, 0)
+
+	for i, s1 := range card.Suits {
+		for j, s2 := range card.Suits {
+
+			// Pair needs two different suits without duplicates
+			if hc.high == hc.low && i >= j {
+				continue
+			}
+
+			if hc.suitedness == suitednessSuited && s1 != s2 {
+				continue
+			}
+
+			if hc.suitedness == suitednessOffsuit && s1 == s2 {
+				continue
+			}
+
+			c1, _ := card.New(hc.high, s1)
+			c2, _ := card.New(hc.low, s2)
+			combos = append(combos, []card.Card{c1, c2})
+		}
+	}
+
+	return combos
+}
+
+// Add puts hole cards into range, weight will be replaced if it exists already.
+func (r *Range) Add(cards []card.Card, weight float64) {
+
+	key := card.NewCardSet(cards...)
+
+	if rc, ok := r.index[key]; ok {
+		rc.Weight = weight
+		return
+	}
+
+	rc := &RangeCombo{
+		Cards:  cards,
+		Weight: weight,
+	}
+
+	r.combos = append(r.combos, rc)
+	r.index[key] = rc
+}
+
+func (r *Range) Combos() []*RangeCombo {
+	return r.combos
+}
+
+// Count returns the number of combinations
+func (r *Range) Count() int {
+	return len(r.combos)
+}
+
+// WeightedCount returns the number of combinations with weights
+func (r *Range) WeightedCount() float64 {
+
+	total := 0.0
+	for _, rc := range r.combos {
+		total += rc.Weight
+	}
+
+	return total
+}
+
+// Weight returns weight of hole cards, it is 0 if hole cards are not in range.
+func (r *Range) Weight(cards []card.Card) float64 {
+
+	rc, ok := r.index[card.NewCardSet(cards...)]
+	if !ok {
+		return 0
+	}
+
+	return rc.Weight
+}
+
+func (r *Range) Contains(cards []card.Card) bool {
+	_, ok := r.index[card.NewCardSet(cards...)]
+	return ok
+}
+
+// WithoutDeadCards returns a new range without combinations which are blocked by dead cards.
+func (r *Range) WithoutDeadCards(dead card.CardSet) *Range {
+
+	nr := NewRange(r.minRank)
+
+	for _, rc := range r.combos {
+
+		if !card.NewCardSet(rc.Cards...).Intersect(dead).IsEmpty() {
+			continue
+		}
+
+		nr.Add(rc.Cards, rc.Weight)
+	}
+
+	return nr
+}
+
+// Classes returns hand classes of range (e.g. "AKs", "TT") in descending order.
+func (r *Range) Classes() []string {
+
+	classes := make(map[string]bool)
+	for _, rc := range r.combos {
+		classes[GetHandClass(rc.Cards)] = true
+	}
+
+	result := make([]string, 0, len(classes))
+	for c := range classes {
+		result = append(result, c)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		a := result[i]
+		b := result[j]
+
+		if a[0] != b[0] {
+			return card.RankBySymbol[a[0:1]] > card.RankBySymbol[b[0:1]]
+		}
+
+		if a[1] != b[1] {
+			return card.RankBySymbol[a[1:2]] > card.RankBySymbol[b[1:2]]
+		}
+
+		return a > b
+	})
+
+	return result
+}
+
+// GetHandClass returns hand class of hole cards (e.g. "AKs", "KQo" and "TT").
+func GetHandClass(cards []card.Card) string {
+
+	if len(cards) != 2 {
+		return ""
+	}
+
+	high := cards[0]
+	low := cards[1]
+	if high.Rank() < low.Rank() {
+		high, low = low, high
+	}
+
+	class := card.RankSymbols[high.Rank()] + card.RankSymbols[low.Rank()]
+
+	if high.Rank() == low.Rank() {
+		return class
+	}
+
+	if high.Suit() == low.Suit() {
+		return class + "s"
+	}
+
+	return class + "o"
+}
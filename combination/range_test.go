@@ -0,0 +1,130 @@
+package combination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokerface/card"
+)
+
+func TestParseRange_Counts(t *testing.T) {
+
+	cases := map[string]int{
+		"AA":      6,
+		"AKs":     4,
+		"AKo":     12,
+		"AK":      16,
+		"TT+":     30,
+		"22+":     78,
+		"TT-77":   24,
+		"A5s-A2s": 16,
+		"ATs+":    16,
+		"KQo-K9o": 48,
+		"76s+":    32,
+		"76s-54s": 12,
+		"SAHK":    1,
+		"AK, AKs": 16,
+	}
+
+	for notation, count := range cases {
+		r, err := ParseRange(notation)
+		assert.Nil(t, err, notation)
+		assert.Equal(t, count, r.Count(), notation)
+	}
+
+	// AKs is included by 76s+ already
+	count, err := CountCombos("TT+, AKs, A5s-A2s, KQo, 76s+")
+	assert.Nil(t, err)
+	assert.Equal(t, 90, count)
+}
+
+func TestParseRange_Classes(t *testing.T) {
+
+	r, err := ParseRange("A5s-A3s, 99+, 87s+")
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{
+		"AA", "AKs", "A5s", "A4s", "A3s",
+		"KK", "KQs", "QQ", "QJs", "JJ", "JTs", "TT", "T9s", "99", "98s", "87s",
+	}, r.Classes())
+}
+
+func TestParseRange_Weights(t *testing.T) {
+
+	r, err := ParseRange("QQ, AKs:0.5")
+	assert.Nil(t, err)
+
+	assert.Equal(t, 10, r.Count())
+	assert.Equal(t, 8.0, r.WeightedCount())
+	assert.Equal(t, 0.5, r.Weight(card.MustParseCards("SA", "SK")))
+	assert.Equal(t, 1.0, r.Weight(card.MustParseCards("HQ", "SQ")))
+	assert.Equal(t, 0.0, r.Weight(card.MustParseCards("HA", "SK")))
+	assert.True(t, r.Contains(card.MustParseCards("SK", "SA")))
+
+	// Weight will be replaced
+	r, err = ParseRange("AKs:0.5, SASK:0.25")
+	assert.Nil(t, err)
+	assert.Equal(t, 4, r.Count())
+	assert.Equal(t, 1.75, r.WeightedCount())
+}
+
+func TestRange_WithoutDeadCards(t *testing.T) {
+
+	r, err := ParseRange("AA, AKs")
+	assert.Nil(t, err)
+
+	nr := r.WithoutDeadCards(card.NewCardSet(card.MustParseCards("SA", "HK")...))
+
+	// AA: 3 combos left, AKs: DA DK and CA CK
+	assert.Equal(t, 5, nr.Count())
+	assert.Equal(t, 10, r.Count())
+
+	for _, rc := range nr.Combos() {
+		assert.NotContains(t, rc.Cards, card.MustParse("SA"))
+		assert.NotContains(t, rc.Cards, card.MustParse("HK"))
+	}
+}
+
+func TestParseShortDeckRange(t *testing.T) {
+
+	r, err := ParseShortDeckRange("66+, 76s+, A9o")
+	assert.Nil(t, err)
+	assert.Equal(t, 9*6+8*4+12, r.Count())
+
+	for _, notation := range []string{"22+", "A5s-A2s", "54s", "SAH2"} {
+		_, err := ParseShortDeckRange(notation)
+		assert.ErrorIs(t, err, ErrInvalidRange, notation)
+	}
+}
+
+func TestParseRange_Invalid(t *testing.T) {
+
+	notations := []string{
+		"AAs",
+		"AKx",
+		"A",
+		"AKQs",
+		"1K",
+		"AKs-KQo",
+		"AKs-QJs-T9s",
+		"A5s-K2s",
+		"AKs:2",
+		"AKs:x",
+		"SASA",
+		"SAH",
+		":0.5",
+		"AKs,:1",
+	}
+
+	for _, notation := range notations {
+		_, err := ParseRange(notation)
+		assert.ErrorIs(t, err, ErrInvalidRange, notation)
+	}
+}
+
+func TestGetHandClass(t *testing.T) {
+	assert.Equal(t, "AKs", GetHandClass(card.MustParseCards("SK", "SA")))
+	assert.Equal(t, "AKo", GetHandClass(card.MustParseCards("SA", "HK")))
+	assert.Equal(t, "TT", GetHandClass(card.MustParseCards("ST", "DT")))
+	assert.Equal(t, "", GetHandClass(card.MustParseCards("ST")))
+}
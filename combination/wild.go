@@ -0,0 +1,282 @@
+package combination
+
+import (
+	"github.com/weedbox/pokerface/card"
+)
+
+// WildEvaluator wraps an evaluator to support wild cards. Jokers are always
+// wild, cards of wild ranks (e.g. deuces) are wild as well. Every wild card
+// is substituted by the card which makes the best combination.
+//
+// Five of a kind is only possible with wild cards, so PowerRankings should
+// have CombinationFiveOfAKind to take it into account.
+type WildEvaluator struct {
+	base      Evaluator
+	wildRanks map[int]bool
+}
+
+func NewWildEvaluator(base Evaluator, wildRanks []int) *WildEvaluator {
+
+	we := &WildEvaluator{
+		base:      base,
+		wildRanks: make(map[int]bool),
+	}
+
+	for _, r := range wildRanks {
+		we.wildRanks[r] = true
+	}
+
+	return we
+}
+
+func (we *WildEvaluator) IsWild(c card.Card) bool {
+	return c.IsJoker() || we.wildRanks[c.Rank()]
+}
+
+func (we *WildEvaluator) split(cards []card.Card) ([]card.Card, []card.Card) {
+
+	naturals := make([]card.Card, 0, len(cards))
+	wilds := make([]card.Card, 0)
+	for _, c := range cards {
+		if we.IsWild(c) {
+			wilds = append(wilds, c)
+		} else {
+			naturals = append(naturals, c)
+		}
+	}
+
+	return naturals, wilds
+}
+
+func (we *WildEvaluator) CalculatePower(cards []card.Card) *PowerState {
+
+	naturals, wilds := we.split(cards)
+	if len(wilds) == 0 {
+		return we.base.CalculatePower(cards)
+	}
+
+	// Find the best substitution with fast evaluation first
+	var best []card.Card
+	bestScore := uint64(0)
+	we.substitute(naturals, len(wilds), func(subs []card.Card) {
+
+		candidate := append(append(make([]card.Card, 0, len(cards)), naturals...), subs...)
+		score := we.base.Evaluate(candidate)
+		if best == nil || score > bestScore {
+			best = candidate
+			bestScore = score
+		}
+	})
+
+	var ps *PowerState
+	if best != nil {
+		ps = we.base.CalculatePower(best)
+	}
+
+	if five := we.calculateFiveOfAKind(naturals, wilds); five != nil {
+		if ps == nil || five.Score > ps.Score {
+			ps = five
+			best = nil
+		}
+	}
+
+	if best != nil {
+		we.markWilds(ps, best[len(naturals):], wilds)
+	}
+
+	return ps
+}
+
+func (we *WildEvaluator) Evaluate(cards []card.Card) uint64 {
+
+	naturals, wilds := we.split(cards)
+	if len(wilds) == 0 {
+		return we.base.Evaluate(cards)
+	}
+
+	// The best 5 cards of substituted cards is always the best combination,
+	// because wild cards which are not used can be anything.
+	best := uint64(0)
+	candidate := make([]card.Card, len(cards))
+	copy(candidate, naturals)
+	we.substitute(naturals, len(wilds), func(subs []card.Card) {
+
+		copy(candidate[len(naturals):], subs)
+		score := we.base.Evaluate(candidate)
+		if score > best {
+			best = score
+		}
+	})
+
+	if five := we.calculateFiveOfAKind(naturals, wilds); five != nil && five.Score > best {
+		best = five.Score
+	}
+
+	return best
+}
+
+// calculateFiveOfAKind returns the best five of a kind, wild cards are
+// substituted by the same card as natural one. It returns nil if there is
+// no five of a kind.
+func (we *WildEvaluator) calculateFiveOfAKind(naturals []card.Card, wilds []card.Card) *PowerState {
+
+	if len(naturals)+len(wilds) < 5 {
+		return nil
+	}
+
+	counts := make(map[int][]card.Card)
+	for _, c := range naturals {
+		counts[c.Rank()] = append(counts[c.Rank()], c)
+	}
+
+	var best *PowerState
+	for r := card.MinRank; r <= card.MaxRank; r++ {
+
+		matched := counts[r]
+		required := 5 - len(matched)
+		if required <= 0 || required > len(wilds) {
+			continue
+		}
+
+		// Wild cards only
+		rep, _ := card.New(r, card.SuitSpade)
+		if len(matched) > 0 {
+			rep = matched[0]
+		}
+
+		subs := make([]card.Card, required)
+		for i := range subs {
+			subs[i] = rep
+		}
+
+		cards := append(append(make([]card.Card, 0, 5), matched...), subs...)
+		ps := we.base.CalculatePower(cards)
+		if best == nil || ps.Score > best.Score {
+			we.markWilds(ps, subs, wilds)
+			best = ps
+		}
+	}
+
+	return best
+}
+
+// substitute walks through all useful substitutions of wild cards. Ranks of
+// substitutions are picked as multiset, suits only matter for flush so that
+// each rank multiset is tried with a neutral suit and with every suit which
+// can be flush.
+func (we *WildEvaluator) substitute(naturals []card.Card, count int, fn func(subs []card.Card)) {
+
+	used := card.NewCardSet(naturals...)
+
+	var suitCounts [4]int
+	for _, c := range naturals {
+		suitCounts[c.Suit()]++
+	}
+
+	// Suits without natural cards are the same, only one of them is needed
+	flushSuits := make([]card.Suit, 0)
+	neutralSuits := make([]card.Suit, 0)
+	emptyFlushSuit := false
+	for _, s := range card.Suits {
+
+		if suitCounts[s]+count < 5 {
+			neutralSuits = append(neutralSuits, s)
+			continue
+		}
+
+		if suitCounts[s] == 0 {
+			if emptyFlushSuit {
+				continue
+			}
+
+			emptyFlushSuit = true
+		}
+
+		flushSuits = append(flushSuits, s)
+	}
+
+	// Neutral suits are preferred to avoid flush
+	preferred := append(append(make([]card.Suit, 0, 4), neutralSuits...), card.Suits...)
+
+	ranks := make([]int, count)
+	subs := make([]card.Card, count)
+
+	assign := func(flushSuit *card.Suit) bool {
+
+		taken := used
+		for i, r := range ranks {
+
+			found := false
+			for _, s := range preferred {
+
+				if flushSuit != nil {
+					s = *flushSuit
+				}
+
+				c, _ := card.New(r, s)
+				if !taken.Contains(c) {
+					subs[i] = c
+					taken = taken.Add(c)
+					found = true
+					break
+				}
+
+				if flushSuit != nil {
+					break
+				}
+			}
+
+			if !found {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	var walk func(start int, depth int)
+	walk = func(start int, depth int) {
+
+		if depth == count {
+
+			if assign(nil) {
+				fn(subs)
+			}
+
+			for i := range flushSuits {
+				if assign(&flushSuits[i]) {
+					fn(subs)
+				}
+			}
+
+			return
+		}
+
+		for r := start; r <= card.MaxRank; r++ {
+			ranks[depth] = r
+			walk(r, depth+1)
+		}
+	}
+
+	walk(card.MinRank, 0)
+}
+
+// markWilds records original wild cards for substitutions in power state
+func (we *WildEvaluator) markWilds(ps *PowerState, subs []card.Card, wilds []card.Card) {
+
+	marked := make([]bool, len(ps.Cards))
+	for i, sub := range subs {
+
+		for j, c := range ps.Cards {
+
+			if marked[j] || c.Rank != sub.Rank() || c.Suit != sub.Suit().String() {
+				continue
+			}
+
+			wild := wilds[i]
+			c.Wild = &wild
+			marked[j] = true
+			break
+		}
+	}
+}
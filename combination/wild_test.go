@@ -0,0 +1,166 @@
+package combination
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokerface/card"
+)
+
+func bruteForceWild(e Evaluator, we *WildEvaluator, cards []card.Card) uint64 {
+
+	naturals, wilds := we.split(cards)
+	used := card.NewCardSet(naturals...)
+
+	best := uint64(0)
+	candidate := append(make([]card.Card, 0, len(cards)), naturals...)
+	var walk func(depth int)
+	walk = func(depth int) {
+
+		if depth == len(wilds) {
+			if score := e.Evaluate(candidate); score > best {
+				best = score
+			}
+			return
+		}
+
+		for _, s := range card.Suits {
+			for r := card.MinRank; r <= card.MaxRank; r++ {
+
+				c, _ := card.New(r, s)
+				if used.Contains(c) {
+					continue
+				}
+
+				used = used.Add(c)
+				candidate = append(candidate, c)
+				walk(depth + 1)
+				candidate = candidate[:len(candidate)-1]
+				used = used.Remove(c)
+			}
+		}
+	}
+
+	walk(0)
+
+	return best
+}
+
+func TestWildEvaluator_FiveOfAKind(t *testing.T) {
+
+	we := NewWildEvaluator(NewHighEvaluator(CombinationPowerWild), nil)
+
+	ps := we.CalculatePower(card.MustParseCards("SA", "HA", "JR", "DA", "CA"))
+	assert.Equal(t, CombinationFiveOfAKind, ps.Combination)
+	assert.Equal(t, 14, ps.Elements[0].Rank)
+
+	wilds := 0
+	for _, c := range ps.Cards {
+		if c.Wild != nil {
+			assert.Equal(t, card.JokerRed, *c.Wild)
+			wilds++
+		}
+
+		cc, err := c.ToCard()
+		assert.Nil(t, err)
+		assert.Contains(t, card.MustParseCards("SA", "HA", "JR", "DA", "CA"), cc)
+	}
+	assert.Equal(t, 1, wilds)
+
+	// Five of a kind beats straight flush
+	sf := we.Evaluate(card.MustParseCards("SA", "SK", "SQ", "SJ", "ST"))
+	assert.Greater(t, ps.Score, sf)
+	assert.Equal(t, ps.Score, we.Evaluate(card.MustParseCards("SA", "HA", "JR", "DA", "CA", "S2", "S3")))
+}
+
+func TestWildEvaluator_WildRanks(t *testing.T) {
+
+	we := NewWildEvaluator(NewHighEvaluator(CombinationPowerWild), []int{2})
+
+	// Deuce makes royal flush
+	ps := we.CalculatePower(card.MustParseCards("SA", "SK", "SQ", "H2", "ST"))
+	assert.Equal(t, CombinationStraightFlush, ps.Combination)
+	assert.Equal(t, 14, ps.Elements[0].Rank)
+
+	cards := make([]card.Card, 0)
+	for _, c := range ps.Cards {
+		cc, _ := c.ToCard()
+		cards = append(cards, cc)
+	}
+	assert.ElementsMatch(t, card.MustParseCards("SA", "SK", "SQ", "H2", "ST"), cards)
+
+	// Four deuces and a joker
+	ps = we.CalculatePower(card.MustParseCards("S2", "H2", "D2", "C2", "JB"))
+	assert.Equal(t, CombinationFiveOfAKind, ps.Combination)
+	assert.Equal(t, 14, ps.Elements[0].Rank)
+
+	// Without wild rank
+	we = NewWildEvaluator(NewHighEvaluator(CombinationPowerWild), nil)
+	ps = we.CalculatePower(card.MustParseCards("SA", "SK", "SQ", "H2", "ST"))
+	assert.Equal(t, CombinationHighCard, ps.Combination)
+}
+
+func TestWildEvaluator_Lowball(t *testing.T) {
+
+	we := NewWildEvaluator(NewLowballA5Evaluator(nil), nil)
+	wheel := NewLowballA5Evaluator(nil).Evaluate(card.MustParseCards("SA", "H2", "D3", "C4", "S5"))
+
+	assert.Equal(t, wheel, we.Evaluate(card.MustParseCards("SA", "H2", "JR", "C4", "S5", "SK", "HK")))
+
+	// Flush is ignored by 2-7 rules, so joker should not complete it
+	we = NewWildEvaluator(NewLowball27Evaluator(nil), nil)
+	ps := we.CalculatePower(card.MustParseCards("S7", "S5", "S4", "S3", "JB"))
+	assert.Equal(t, CombinationHighCard, ps.Combination)
+	assert.Equal(t, 2, ps.Elements[len(ps.Elements)-1].Rank)
+}
+
+func TestWildEvaluator_SameAsBruteForce(t *testing.T) {
+
+	r := rand.New(rand.NewSource(1))
+
+	deck := card.MustParseCards(newTestDeck(2)...)
+	deck = append(deck, card.JokerBlack, card.JokerRed)
+
+	high := NewHighEvaluator(CombinationPowerWild)
+	lowball := NewLowball27Evaluator(nil)
+	for _, e := range []Evaluator{high, lowball} {
+
+		we := NewWildEvaluator(e, []int{2})
+		for i := 0; i < 200; i++ {
+
+			r.Shuffle(len(deck), func(i, j int) {
+				deck[i], deck[j] = deck[j], deck[i]
+			})
+
+			count := 5 + i%3
+			cards := deck[:count]
+
+			// Brute force is too slow for a lot of wild cards
+			if _, wilds := we.split(cards); len(wilds) > 2 || (e == lowball && count > 5) {
+				continue
+			}
+
+			expected := bruteForceWild(e, we, cards)
+			score := we.Evaluate(cards)
+			if score < expected {
+				t.Fatalf("%v: expected %d, got %d", cards, expected, score)
+			}
+
+			if count == 5 {
+				assert.Equal(t, score, we.CalculatePower(cards).Score, cards)
+			}
+		}
+	}
+}
+
+func BenchmarkWildEvaluator_7Cards2Wilds(b *testing.B) {
+
+	we := NewWildEvaluator(NewHighEvaluator(CombinationPowerWild), []int{2})
+	cards := card.MustParseCards("SA", "HK", "D2", "JR", "S9", "C7", "H4")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		we.Evaluate(cards)
+	}
+}
@@ -0,0 +1,159 @@
+package pokerface
+
+import (
+	"errors"
+
+	"github.com/weedbox/pokerface/card"
+)
+
+var (
+	ErrDealingNotSet = errors.New("game: dealing is not set")
+)
+
+// Dealing provides cards for Deal and Burn. The default dealing takes cards
+// from deck in secrets, other implementations (e.g. mental poker) are able
+// to deal without a trusted deck.
+//
+// Dealing is not a part of state, it should be set again after game was
+// restored from state.
+type Dealing interface {
+	Shuffle() error
+	Deal(count int) ([]card.Card, error)
+	Burn(count int) error
+}
+
+// HoleCardsDealing is dealing which reveals hole cards to the player only,
+// instead of dealing them to everyone.
+//
+// Note that game is a trusted party for hole cards: it gets them in plain to
+// evaluate hands, and keeps them in state of players. Other players never see
+// them as long as state is redacted for viewers.
+type HoleCardsDealing interface {
+	DealHoleCards(playerIdx int, count int) ([]card.Card, error)
+}
+
+type deckDealing struct {
+	g *game
+}
+
+func (dd *deckDealing) Shuffle() error {
+	dd.g.secrets.Deck = ShuffleCards(dd.g.secrets.Deck)
+	return nil
+}
+
+func (dd *deckDealing) Deal(count int) ([]card.Card, error) {
+
+	deck := dd.g.secrets.Deck
+	if count > len(deck) {
+		return nil, ErrNotEnoughCards
+	}
+
+	cards := make([]card.Card, 0, count)
+	cards = append(cards, deck[:count]...)
+
+	dd.g.secrets.Deck = deck[count:]
+
+	return cards, nil
+}
+
+func (dd *deckDealing) Burn(count int) error {
+
+	cards, err := dd.Deal(count)
+	if err != nil {
+		return err
+	}
+
+	dd.g.secrets.Burned = append(dd.g.secrets.Burned, cards...)
+
+	return nil
+}
+
+// SetDealing replaces the default dealing, game remembers that cards come
+// from dealing and plain deck is no longer kept in secrets.
+func (g *game) SetDealing(d Dealing) {
+
+	g.dealing = d
+
+	if d == nil {
+		return
+	}
+
+	g.gs.Meta.CardSource = CardSourceDealing
+	g.secrets.Deck = make([]card.Card, 0)
+}
+
+func (g *game) getDealing() (Dealing, error) {
+
+	if g.isExternal() && g.external != nil {
+		return g.external, nil
+	}
+
+	if g.dealing != nil {
+		return g.dealing, nil
+	}
+
+	// Dealing must be set again after game was restored
+	if g.gs.Meta.CardSource == CardSourceDealing {
+		return nil, ErrDealingNotSet
+	}
+
+	return &deckDealing{g: g}, nil
+}
+
+func (g *game) deal(count int) ([]card.Card, error) {
+
+	d, err := g.getDealing()
+	if err != nil {
+		return nil, err
+	}
+
+	cards, err := d.Deal(count)
+	if err != nil {
+		return nil, err
+	}
+
+	g.gs.Status.CurrentDeckPosition += len(cards)
+
+	return cards, nil
+}
+
+// dealHoleCards deals cards to player privately if dealing supports it, cards
+// are known by game in plain anyway.
+func (g *game) dealHoleCards(idx int, count int) ([]card.Card, error) {
+
+	d, err := g.getDealing()
+	if err != nil {
+		return nil, err
+	}
+
+	hd, ok := d.(HoleCardsDealing)
+	if !ok {
+		return g.deal(count)
+	}
+
+	cards, err := hd.DealHoleCards(idx, count)
+	if err != nil {
+		return nil, err
+	}
+
+	g.gs.Status.CurrentDeckPosition += len(cards)
+
+	return cards, nil
+}
+
+func (g *game) burn(count int) error {
+
+	d, err := g.getDealing()
+	if err != nil {
+		return err
+	}
+
+	err = d.Burn(count)
+	if err != nil {
+		return err
+	}
+
+	g.gs.Status.CurrentDeckPosition += count
+
+	return nil
+}
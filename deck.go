@@ -1,9 +1,10 @@
 package pokerface
 
 import (
-	"fmt"
 	"math/rand"
 	"time"
+
+	"github.com/weedbox/pokerface/card"
 )
 
 type CardSuit int32
@@ -38,35 +39,44 @@ var CardPoints = []string{
 	"A",
 }
 
-func NewStandardDeckCards() []string {
+func newDeckCards(minRank int) []card.Card {
 
-	cards := make([]string, 0, 52)
+	cards := make([]card.Card, 0, 52)
 
-	for _, suit := range CardSuits {
-		for i := 0; i < 13; i++ {
-			cards = append(cards, fmt.Sprintf("%s%s", suit, CardPoints[i]))
+	for _, suit := range card.Suits {
+		for r := minRank; r <= card.MaxRank; r++ {
+			c, _ := card.New(r, suit)
+			cards = append(cards, c)
 		}
 	}
 
 	return cards
 }
 
-func NewShortDeckCards() []string {
+func NewStandardDeckCards() []card.Card {
+	return newDeckCards(card.MinRank)
+}
+
+func NewShortDeckCards() []card.Card {
 
-	cards := make([]string, 0, 36)
+	// Take off 2, 3, 4 and 5
+	return newDeckCards(6)
+}
 
-	for _, suit := range CardSuits {
+// NewJokerDeckCards returns standard deck with jokers, two jokers at most.
+func NewJokerDeckCards(jokers int) []card.Card {
 
-		// Take off 2, 3, 4 and 5
-		for i := 4; i < 13; i++ {
-			cards = append(cards, fmt.Sprintf("%s%s", suit, CardPoints[i]))
+	cards := NewStandardDeckCards()
+	for i, joker := range []card.Card{card.JokerBlack, card.JokerRed} {
+		if i < jokers {
+			cards = append(cards, joker)
 		}
 	}
 
 	return cards
 }
 
-func ShuffleCards(cards []string) []string {
+func ShuffleCards(cards []card.Card) []card.Card {
 
 	rand.Seed(time.Now().UnixNano())
 
@@ -1,5 +1,15 @@
 package pokerface
 
+import "errors"
+
+var (
+	ErrEventLoop = errors.New("game: too many chained events")
+)
+
+// maxChainedEvents limits events which are emitted by handlers of other events
+// in a single call, a normal hand never chains more than a few dozen.
+const maxChainedEvents = 256
+
 type GameEvent int32
 
 const (
@@ -30,6 +40,10 @@ const (
 	GameEvent_SettlementRequested
 	GameEvent_SettlementCompleted
 	GameEvent_GameClosed
+
+	// Dealing
+	GameEvent_CardsRequested
+	GameEvent_GameVoided
 )
 
 var GameEventSymbols = map[GameEvent]string{
@@ -54,6 +68,8 @@ var GameEventSymbols = map[GameEvent]string{
 	GameEvent_SettlementRequested: "SettlementRequested",
 	GameEvent_SettlementCompleted: "SettlementCompleted",
 	GameEvent_GameClosed:          "GameClosed",
+	GameEvent_CardsRequested:      "CardsRequested",
+	GameEvent_GameVoided:          "GameVoided",
 }
 
 var GameEventBySymbol = map[string]GameEvent{
@@ -78,12 +94,12 @@ var GameEventBySymbol = map[string]GameEvent{
 	"SettlementRequested": GameEvent_SettlementRequested,
 	"SettlementCompleted": GameEvent_SettlementCompleted,
 	"GameClosed":          GameEvent_GameClosed,
+	"CardsRequested":      GameEvent_CardsRequested,
+	"GameVoided":          GameEvent_GameVoided,
 }
 
 func (g *game) triggerEvent(event GameEvent) error {
 
-	defer g.onBreakPoint()
-
 	switch event {
 
 	case GameEvent_Started:
@@ -147,6 +163,12 @@ func (g *game) triggerEvent(event GameEvent) error {
 		return g.onSettlementCompleted()
 
 	case GameEvent_GameClosed:
+
+	case GameEvent_CardsRequested:
+		return g.onCardsRequested()
+
+	case GameEvent_GameVoided:
+		return g.onGameVoided()
 	}
 
 	return nil
@@ -157,7 +179,59 @@ func (g *game) EmitEvent(event GameEvent) error {
 	// Update current event
 	g.gs.Status.CurrentEvent = GameEventSymbols[event]
 
-	return g.triggerEvent(event)
+	// Emitted by handler, it will be triggered after handler returned
+	if g.dispatching {
+		g.pending = append(g.pending, event)
+		return nil
+	}
+
+	return g.dispatch(event)
+}
+
+// dispatch triggers event and all events which are emitted by handlers one by
+// one, so that call stack doesn't grow with the event chain. Break point is
+// reached after every event.
+func (g *game) dispatch(event GameEvent) error {
+
+	g.dispatching = true
+	defer func() {
+		g.dispatching = false
+		g.pending = nil
+	}()
+
+	queue := []GameEvent{event}
+	for steps := 0; len(queue) > 0; steps++ {
+
+		// Events keep emitting each other without making progress
+		if steps >= maxChainedEvents {
+			return ErrEventLoop
+		}
+
+		event, queue = queue[0], queue[1:]
+		g.gs.Status.CurrentEvent = GameEventSymbols[event]
+
+		err := g.triggerEvent(event)
+
+		queue = append(queue, g.pending...)
+		g.pending = g.pending[:0]
+
+		// Events which were emitted by handler are not triggered yet, break
+		// point sees the event which was triggered
+		g.gs.Status.CurrentEvent = GameEventSymbols[event]
+
+		// State is settled if no more event is going to be triggered
+		saveErr := g.onBreakPoint(len(queue) == 0)
+
+		if err != nil {
+			return err
+		}
+
+		if saveErr != nil {
+			return saveErr
+		}
+	}
+
+	return nil
 }
 
 func (g *game) GetEvent() string {
@@ -292,6 +366,14 @@ func (g *game) onSettlementCompleted() error {
 	return g.EmitEvent(GameEvent_GameClosed)
 }
 
+func (g *game) onCardsRequested() error {
+	return nil
+}
+
+func (g *game) onGameVoided() error {
+	return nil
+}
+
 func (g *game) onGameClosed() error {
 	return nil
 }
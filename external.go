@@ -0,0 +1,215 @@
+package pokerface
+
+import (
+	"errors"
+
+	"github.com/weedbox/pokerface/card"
+)
+
+var (
+	ErrCardsNotRequested = errors.New("game: cards are not requested")
+	ErrDuplicateCard     = errors.New("game: duplicate card")
+	ErrTooManyCards      = errors.New("game: too many cards")
+	ErrNotFoundCard      = errors.New("game: not found card")
+	ErrRoundStarted      = errors.New("game: round was started already")
+)
+
+const (
+	CardSourceDeck     = "deck"
+	CardSourceExternal = "external"
+
+	// Cards are dealt by Dealing which was set to game (e.g. mental poker)
+	CardSourceDealing = "dealing"
+)
+
+// CardsRequest is cards which are required by round in external mode, cards
+// are pushed from outside (e.g. RFID shoe of live dealer). Pushed cards are
+// kept in secrets, Cards is only left by state of older version.
+type CardsRequest struct {
+	Round string      `json:"round"`
+	Count int         `json:"count"`
+	Cards []card.Card `json:"cards,omitempty"`
+}
+
+// externalDealing deals cards which were pushed for the current round,
+// burned cards are not tracked.
+type externalDealing struct {
+	cards []card.Card
+	pos   int
+}
+
+func (ed *externalDealing) Shuffle() error {
+	return nil
+}
+
+func (ed *externalDealing) Deal(count int) ([]card.Card, error) {
+
+	if ed.pos+count > len(ed.cards) {
+		return nil, ErrNotEnoughCards
+	}
+
+	cards := make([]card.Card, 0, count)
+	cards = append(cards, ed.cards[ed.pos:ed.pos+count]...)
+	ed.pos += count
+
+	return cards, nil
+}
+
+func (ed *externalDealing) Burn(count int) error {
+	return nil
+}
+
+func (g *game) isExternal() bool {
+	return g.gs.Meta.CardSource == CardSourceExternal
+}
+
+// requiredCards returns the number of cards which are dealt in round
+func (g *game) requiredCards() int {
+
+	switch g.gs.Status.Round {
+	case "preflop":
+		return len(g.gs.Players) * g.gs.Meta.HoleCardsCount
+	case "flop":
+		return 3
+	case "turn", "river":
+		return 1
+	}
+
+	return 0
+}
+
+// prepareExternalCards returns false if cards of round are not ready yet
+func (g *game) prepareExternalCards() (bool, error) {
+
+	req := g.gs.Status.CardsRequest
+	if req != nil && req.Round == g.gs.Status.Round && len(g.secrets.Pushed) == req.Count {
+		g.external = &externalDealing{
+			cards: g.secrets.Pushed,
+		}
+
+		// Cards are dealt to players and board, nothing else is public
+		g.gs.Status.CardsRequest = &CardsRequest{
+			Round: req.Round,
+			Count: req.Count,
+		}
+
+		return true, nil
+	}
+
+	g.gs.Status.CardsRequest = &CardsRequest{
+		Round: g.gs.Status.Round,
+		Count: g.requiredCards(),
+	}
+
+	g.secrets.Pushed = make([]card.Card, 0)
+
+	return false, g.EmitEvent(GameEvent_CardsRequested)
+}
+
+// usedCards returns cards which are on table already
+func (g *game) usedCards() card.CardSet {
+
+	used := card.NewCardSet(g.gs.Status.Board...)
+	for _, p := range g.gs.Players {
+		used = used.Add(p.HoleCards...)
+	}
+
+	used = used.Add(g.secrets.Pushed...)
+
+	return used
+}
+
+// PushCards accepts cards from outside in dealing order, round continues once
+// all of requested cards were pushed.
+func (g *game) PushCards(cards ...card.Card) error {
+
+	req := g.gs.Status.CardsRequest
+	if g.gs.Status.CurrentEvent != "CardsRequested" || req == nil {
+		return ErrCardsNotRequested
+	}
+
+	if len(g.secrets.Pushed)+len(cards) > req.Count {
+		return ErrTooManyCards
+	}
+
+	used := g.usedCards()
+	for _, c := range cards {
+
+		if !c.IsValid() {
+			return card.ErrInvalidCard
+		}
+
+		if used.Contains(c) {
+			return ErrDuplicateCard
+		}
+
+		used = used.Add(c)
+	}
+
+	g.secrets.Pushed = append(g.secrets.Pushed, cards...)
+
+	// Pushed cards are kept until all of them are ready
+	if len(g.secrets.Pushed) < req.Count {
+		return g.saveSecrets()
+	}
+
+	return g.InitializeRound()
+}
+
+// CorrectCard replaces a misread card of the current round, it is only
+// allowed before round starts.
+func (g *game) CorrectCard(misread card.Card, actual card.Card) error {
+
+	req := g.gs.Status.CardsRequest
+	if req == nil || req.Round != g.gs.Status.Round {
+		return ErrCardsNotRequested
+	}
+
+	switch g.gs.Status.CurrentEvent {
+	case "CardsRequested", "BlindsRequested", "ReadyRequested":
+	default:
+		return ErrRoundStarted
+	}
+
+	if !actual.IsValid() {
+		return card.ErrInvalidCard
+	}
+
+	if g.usedCards().Contains(actual) {
+		return ErrDuplicateCard
+	}
+
+	found := replaceCard(g.secrets.Pushed, misread, actual)
+	if !found {
+		return ErrNotFoundCard
+	}
+
+	err := g.saveSecrets()
+	if err != nil {
+		return err
+	}
+
+	// Cards were dealt already
+	if g.gs.Status.CurrentEvent == "CardsRequested" {
+		return nil
+	}
+
+	replaceCard(g.gs.Status.Board, misread, actual)
+	for _, p := range g.gs.Players {
+		replaceCard(p.HoleCards, misread, actual)
+	}
+
+	return g.UpdateCombinationOfAllPlayers()
+}
+
+func replaceCard(cards []card.Card, old card.Card, c card.Card) bool {
+
+	for i, oc := range cards {
+		if oc == old {
+			cards[i] = c
+			return true
+		}
+	}
+
+	return false
+}
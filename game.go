@@ -6,11 +6,13 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/weedbox/pokerface/card"
 	"github.com/weedbox/pokerface/pot"
 )
 
 var (
 	ErrNoDeck                      = errors.New("game: no deck")
+	ErrInvalidDeck                 = errors.New("game: invalid deck")
 	ErrNotEnoughBackroll           = errors.New("game: backroll is not enough")
 	ErrNoDealer                    = errors.New("game: no dealer")
 	ErrInsufficientNumberOfPlayers = errors.New("game: insufficient number of players")
@@ -18,6 +20,9 @@ var (
 	ErrNotFoundDealer              = errors.New("game: not found dealer")
 	ErrUnknownTask                 = errors.New("game: unknown task")
 	ErrNotClosedRound              = errors.New("game: round is not closed")
+	ErrUnknownEvaluator            = errors.New("game: unknown evaluator")
+	ErrUnknownLocale               = errors.New("game: unknown locale")
+	ErrNotEnoughCards              = errors.New("game: not enough cards")
 )
 
 type Game interface {
@@ -28,11 +33,19 @@ type Game interface {
 	GetState() *GameState
 	GetStateJSON() ([]byte, error)
 	LoadState(gs *GameState) error
+	GetSecrets() *Secrets
+	LoadSecrets(s *Secrets) error
+	SetDealing(d Dealing)
+	PushCards(cards ...card.Card) error
+	CorrectCard(misread card.Card, actual card.Card) error
+	Misdeal(reason string) error
+	Audit() []Violation
+	SetAuditor(h AuditHandler)
 	Player(idx int) Player
 	Dealer() Player
 	SmallBlind() Player
 	BigBlind() Player
-	Deal(count int) []string
+	Deal(count int) ([]card.Card, error)
 	Burn(count int) error
 	BecomeRaiser(Player) error
 	ResetActedPlayers() error
@@ -69,11 +82,19 @@ type Game interface {
 }
 
 type game struct {
-	gs         *GameState
-	players    map[int]Player
-	dealer     Player
-	smallBlind Player
-	bigBlind   Player
+	gs          *GameState
+	players     map[int]Player
+	dealer      Player
+	smallBlind  Player
+	bigBlind    Player
+	secrets     *Secrets
+	store       SecretStore
+	dealing     Dealing
+	external    *externalDealing
+	auditor     AuditHandler
+	stacked     bool
+	dispatching bool
+	pending     []GameEvent
 }
 
 func NewGame(opts *GameOptions) *game {
@@ -84,17 +105,61 @@ func NewGame(opts *GameOptions) *game {
 	return g
 }
 
+// NewGameFromState returns nil if state cannot be loaded.
+//
+// Deprecated: use LoadGame which returns the reason.
 func NewGameFromState(gs *GameState) *game {
+
+	g, err := LoadGame(gs)
+	if err != nil {
+		return nil
+	}
+
+	return g
+}
+
+// LoadGame restores game from state, it returns error if state cannot be
+// loaded. Secrets are not in state, so game in progress is refused unless
+// deck is in legacy state. LoadGameWithSecrets loads game with its secrets.
+func LoadGame(gs *GameState) (*game, error) {
+	return LoadGameWithSecrets(gs, nil)
+}
+
+// LoadGameWithSecrets restores game from state and secrets which were saved
+// outside of game.
+func LoadGameWithSecrets(gs *GameState, s *Secrets) (*game, error) {
+
+	legacy := isLegacyState(gs)
+
 	g := &game{
 		players: make(map[int]Player),
 	}
-	g.LoadState(gs)
-	return g
+
+	err := g.LoadState(gs)
+	if err != nil {
+		return nil, err
+	}
+
+	if s != nil {
+		return g, g.LoadSecrets(s)
+	}
+
+	// Closed game has no secrets
+	if !legacy && !isClosedEvent(gs.Status.CurrentEvent) {
+		return nil, ErrSecretsNotFound
+	}
+
+	return g, nil
 }
 
-func (g *game) onBreakPoint() {
+// onBreakPoint returns error if secrets were not saved, game cannot go on
+// without them once it is loaded again.
+func (g *game) onBreakPoint(settled bool) error {
 	g.gs.UpdatedAt = time.Now().UnixNano()
 	//atomic.AddInt64(&g.gs.UpdatedAt, 1)
+	err := g.saveSecrets()
+	g.runAudit(settled)
+	return err
 }
 
 func (g *game) GetState() *GameState {
@@ -105,9 +170,24 @@ func (g *game) GetStateJSON() ([]byte, error) {
 	return json.Marshal(g.gs)
 }
 
+// LoadState upgrades state from older schema version, it refuses newer one.
 func (g *game) LoadState(gs *GameState) error {
+
+	err := migrateState(gs)
+	if err != nil {
+		return err
+	}
+
 	g.gs = gs
 
+	// Deck of legacy state is moved into secrets
+	g.secrets = &Secrets{
+		Deck:   make([]card.Card, 0),
+		Burned: make([]card.Card, 0),
+		Pushed: make([]card.Card, 0),
+	}
+	g.absorbSecrets()
+
 	// Initializing players
 	for _, ps := range g.gs.Players {
 		g.addPlayer(ps)
@@ -134,7 +214,8 @@ func (g *game) Resume() error {
 func (g *game) ApplyOptions(opts *GameOptions) error {
 
 	g.gs = &GameState{
-		Players: make([]*PlayerState, 0),
+		SchemaVersion: SchemaVersion,
+		Players:       make([]*PlayerState, 0),
 		Meta: Meta{
 			Ante:                   opts.Ante,
 			Blind:                  opts.Blind,
@@ -142,11 +223,28 @@ func (g *game) ApplyOptions(opts *GameOptions) error {
 			HoleCardsCount:         opts.HoleCardsCount,
 			RequiredHoleCardsCount: opts.RequiredHoleCardsCount,
 			CombinationPowers:      opts.CombinationPowers,
-			Deck:                   opts.Deck,
+			Evaluator:              opts.Evaluator,
+			Locale:                 opts.Locale,
+			WildRanks:              opts.WildRanks,
 			BurnCount:              opts.BurnCount,
+			CardSource:             opts.CardSource,
+			MisdealLimit:           opts.MisdealLimit,
 		},
 	}
 
+	// Deck is kept privately
+	g.stacked = opts.StackedDeck
+	g.secrets = &Secrets{
+		Deck:   append(make([]card.Card, 0, len(opts.Deck)), opts.Deck...),
+		Burned: make([]card.Card, 0),
+		Pushed: make([]card.Card, 0),
+	}
+
+	// Plain deck is useless if cards come from dealing
+	if opts.CardSource == CardSourceDealing {
+		g.secrets.Deck = make([]card.Card, 0)
+	}
+
 	// Loading players
 	for idx, p := range opts.Players {
 		g.AddPlayer(idx, p)
@@ -217,22 +315,12 @@ func (g *game) BigBlind() Player {
 	return g.bigBlind
 }
 
-func (g *game) Deal(count int) []string {
-
-	cards := make([]string, 0, count)
-
-	finalPos := g.gs.Status.CurrentDeckPosition + count
-	for i := g.gs.Status.CurrentDeckPosition; i < finalPos; i++ {
-		cards = append(cards, g.gs.Meta.Deck[i])
-		g.gs.Status.CurrentDeckPosition++
-	}
-
-	return cards
+func (g *game) Deal(count int) ([]card.Card, error) {
+	return g.deal(count)
 }
 
 func (g *game) Burn(count int) error {
-	g.gs.Status.Burned = append(g.gs.Status.Burned, g.Deal(count)...)
-	return nil
+	return g.burn(count)
 }
 
 func (g *game) ResetAllPlayerAllowedActions() error {
@@ -555,15 +643,37 @@ func (g *game) Start() error {
 		}
 	}
 
-	// No desk was set
-	if len(g.gs.Meta.Deck) == 0 {
+	// Deck might be set in state directly
+	g.absorbSecrets()
+
+	// No desk was set, deck is not needed if cards come from elsewhere
+	if len(g.secrets.Deck) == 0 && !g.isExternal() && g.gs.Meta.CardSource != CardSourceDealing {
 		return ErrNoDeck
 	}
 
+	// Cards must be valid and unique
+	for _, c := range g.secrets.Deck {
+		if !c.IsValid() {
+			return ErrInvalidDeck
+		}
+	}
+
+	if card.NewCardSet(g.secrets.Deck...).Count() != len(g.secrets.Deck) {
+		return ErrInvalidDeck
+	}
+
+	if g.Evaluator() == nil {
+		return ErrUnknownEvaluator
+	}
+
+	if g.Locale() == nil {
+		return ErrUnknownLocale
+	}
+
 	// Initializing game status
 	g.gs.Status.Pots = make([]*pot.Pot, 0)
-	g.gs.Status.Board = make([]string, 0)
-	g.gs.Status.Burned = make([]string, 0)
+	g.gs.Status.Board = make([]card.Card, 0)
+	g.secrets.Burned = make([]card.Card, 0)
 	g.gs.Status.CurrentEvent = ""
 
 	return g.EmitEvent(GameEvent_Started)
@@ -572,7 +682,18 @@ func (g *game) Start() error {
 func (g *game) Initialize() error {
 
 	// Shuffle cards
-	g.gs.Meta.Deck = ShuffleCards(g.gs.Meta.Deck)
+	if !g.stacked {
+
+		d, err := g.getDealing()
+		if err != nil {
+			return err
+		}
+
+		err = d.Shuffle()
+		if err != nil {
+			return err
+		}
+	}
 
 	// Initialize minimum bet
 	if g.gs.Meta.Blind.Dealer > g.gs.Meta.Blind.BB {
@@ -681,23 +802,44 @@ func (g *game) EnterRiverRound() error {
 
 func (g *game) InitializeRound() error {
 
+	// Waiting for cards from outside
+	if g.isExternal() {
+		ready, err := g.prepareExternalCards()
+		if !ready || err != nil {
+			return err
+		}
+	}
+
 	// Initializing for stages (Preflop, Flop, Turn and River)
 	switch g.gs.Status.Round {
 	case "preflop":
 
 		// Deal cards to players
 		for _, p := range g.gs.Players {
-			p.HoleCards = g.Deal(g.gs.Meta.HoleCardsCount)
+			cards, err := g.dealHoleCards(p.Idx, g.gs.Meta.HoleCardsCount)
+			if err != nil {
+				return err
+			}
+
+			p.HoleCards = cards
 		}
 	case "flop":
 
-		g.Burn(1)
+		err := g.burn(1)
+		if err != nil {
+			return err
+		}
 
 		// Deal 3 board cards
-		g.gs.Status.Board = append(g.gs.Status.Board, g.Deal(3)...)
+		cards, err := g.deal(3)
+		if err != nil {
+			return err
+		}
+
+		g.gs.Status.Board = append(g.gs.Status.Board, cards...)
 
 		// Start at dealer
-		_, err := g.StartAtDealer()
+		_, err = g.StartAtDealer()
 		if err != nil {
 			return err
 		}
@@ -706,13 +848,21 @@ func (g *game) InitializeRound() error {
 		fallthrough
 	case "river":
 
-		g.Burn(1)
+		err := g.burn(1)
+		if err != nil {
+			return err
+		}
 
 		// Deal board card
-		g.gs.Status.Board = append(g.gs.Status.Board, g.Deal(1)...)
+		cards, err := g.deal(1)
+		if err != nil {
+			return err
+		}
+
+		g.gs.Status.Board = append(g.gs.Status.Board, cards...)
 
 		// Start at dealer
-		_, err := g.StartAtDealer()
+		_, err = g.StartAtDealer()
 		if err != nil {
 			return err
 		}
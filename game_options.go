@@ -1,6 +1,9 @@
 package pokerface
 
-import "github.com/weedbox/pokerface/combination"
+import (
+	"github.com/weedbox/pokerface/card"
+	"github.com/weedbox/pokerface/combination"
+)
 
 type GameOptions struct {
 	Ante                   int64                     `json:"ante"`
@@ -9,7 +12,13 @@ type GameOptions struct {
 	HoleCardsCount         int                       `json:"hole_cards_count"`
 	RequiredHoleCardsCount int                       `json:"required_hole_cards_count"`
 	CombinationPowers      []combination.Combination `json:"combination_powers"`
-	Deck                   []string                  `json:"deck"`
+	Evaluator              string                    `json:"evaluator"`
+	Locale                 string                    `json:"locale"`
+	WildRanks              []int                     `json:"wild_ranks"`
+	CardSource             string                    `json:"card_source"`
+	MisdealLimit           string                    `json:"misdeal_limit"`
+	Deck                   []card.Card               `json:"deck"`
+	StackedDeck            bool                      `json:"stacked_deck"` // deck is dealt in order without shuffling
 	BurnCount              int                       `json:"burn_count"`
 	Players                []*PlayerSetting          `json:"players"`
 }
@@ -37,7 +46,12 @@ func NewStardardGameOptions() *GameOptions {
 		HoleCardsCount:         2,
 		RequiredHoleCardsCount: 0,
 		CombinationPowers:      combination.CombinationPowerStandard,
-		Deck:                   make([]string, 0),
+		Evaluator:              combination.EvaluatorHigh,
+		Locale:                 combination.LocaleEnglish,
+		WildRanks:              make([]int, 0),
+		CardSource:             CardSourceDeck,
+		MisdealLimit:           MisdealBeforeAction,
+		Deck:                   make([]card.Card, 0),
 		BurnCount:              1,
 		Players:                make([]*PlayerSetting, 0),
 	}
@@ -49,3 +63,21 @@ func NewShortDeckGameOptions() *GameOptions {
 
 	return opts
 }
+
+func NewLowballA5GameOptions() *GameOptions {
+
+	opts := NewStardardGameOptions()
+	opts.CombinationPowers = combination.CombinationPowerLowballA5
+	opts.Evaluator = combination.EvaluatorLowballA5
+
+	return opts
+}
+
+func NewLowball27GameOptions() *GameOptions {
+
+	opts := NewStardardGameOptions()
+	opts.CombinationPowers = combination.CombinationPowerLowball27
+	opts.Evaluator = combination.EvaluatorLowball27
+
+	return opts
+}
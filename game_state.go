@@ -1,19 +1,21 @@
 package pokerface
 
 import (
+	"github.com/weedbox/pokerface/card"
 	"github.com/weedbox/pokerface/combination"
 	"github.com/weedbox/pokerface/pot"
 	"github.com/weedbox/pokerface/settlement"
 )
 
 type GameState struct {
-	GameID    string             `json:"game_id"`
-	CreatedAt int64              `json:"created_at"`
-	UpdatedAt int64              `json:"updated_at"`
-	Meta      Meta               `json:"meta"`
-	Status    Status             `json:"status"`
-	Players   []*PlayerState     `json:"players"`
-	Result    *settlement.Result `json:"result,omitempty"`
+	SchemaVersion int                `json:"schema_version"`
+	GameID        string             `json:"game_id"`
+	CreatedAt     int64              `json:"created_at"`
+	UpdatedAt     int64              `json:"updated_at"`
+	Meta          Meta               `json:"meta"`
+	Status        Status             `json:"status"`
+	Players       []*PlayerState     `json:"players"`
+	Result        *settlement.Result `json:"result,omitempty"`
 }
 
 type Meta struct {
@@ -23,8 +25,13 @@ type Meta struct {
 	HoleCardsCount         int                       `json:"hole_cards_count"`
 	RequiredHoleCardsCount int                       `json:"required_hole_cards_count"`
 	CombinationPowers      combination.PowerRankings `json:"combination_powers"`
-	Deck                   []string                  `json:"deck"`
+	Evaluator              string                    `json:"evaluator,omitempty"`
+	Locale                 string                    `json:"locale,omitempty"`
+	WildRanks              []int                     `json:"wild_ranks,omitempty"`
+	Deck                   []card.Card               `json:"deck,omitempty"` // legacy only, deck is kept in Secrets
 	BurnCount              int                       `json:"burn_count"`
+	CardSource             string                    `json:"card_source,omitempty"`
+	MisdealLimit           string                    `json:"misdeal_limit,omitempty"`
 }
 
 type Action struct {
@@ -34,20 +41,22 @@ type Action struct {
 }
 
 type Status struct {
-	MiniBet             int64      `json:"mini_bet"`
-	MaxWager            int64      `json:"max_wager"`
-	Pots                []*pot.Pot `json:"pots"`
-	Round               string     `json:"round,omitempty"`
-	Burned              []string   `json:"burned,omitempty"`
-	Board               []string   `json:"board,omitempty"`
-	PreviousRaiseSize   int64      `json:"previous_raise_size"`
-	CurrentDeckPosition int        `json:"current_deck_position"`
-	CurrentRoundPot     int64      `json:"current_round_pot"`
-	CurrentWager        int64      `json:"current_wager"`
-	CurrentRaiser       int        `json:"current_raiser"`
-	CurrentPlayer       int        `json:"current_player"`
-	CurrentEvent        string     `json:"current_event"`
-	LastAction          *Action    `json:"last_action,omitempty"`
+	MiniBet             int64         `json:"mini_bet"`
+	MaxWager            int64         `json:"max_wager"`
+	Pots                []*pot.Pot    `json:"pots"`
+	Round               string        `json:"round,omitempty"`
+	Burned              []card.Card   `json:"burned,omitempty"` // legacy only, burned cards are kept in Secrets
+	Board               []card.Card   `json:"board,omitempty"`
+	PreviousRaiseSize   int64         `json:"previous_raise_size"`
+	CurrentDeckPosition int           `json:"current_deck_position"`
+	CurrentRoundPot     int64         `json:"current_round_pot"`
+	CurrentWager        int64         `json:"current_wager"`
+	CurrentRaiser       int           `json:"current_raiser"`
+	CurrentPlayer       int           `json:"current_player"`
+	CurrentEvent        string        `json:"current_event"`
+	LastAction          *Action       `json:"last_action,omitempty"`
+	CardsRequest        *CardsRequest `json:"cards_request,omitempty"`
+	VoidReason          string        `json:"void_reason,omitempty"`
 }
 
 type PlayerState struct {
@@ -63,80 +72,51 @@ type PlayerState struct {
 
 	// Stack and wager
 	Bankroll         int64 `json:"bankroll"`
-	InitialStackSize int64 `json:"initial_stack_size"` // bankroll - pot
+	InitialStackSize int64 `json:"initial_stack_size"` // bankroll - pot - dead_blind
 	StackSize        int64 `json:"stack_size"`         // initial_stack_size - wager
 	Pot              int64 `json:"pot"`
 	Wager            int64 `json:"wager"`
+	DeadBlind        int64 `json:"dead_blind,omitempty"` // goes to main pot, not counted as contribution
 
 	// Hole cards information
-	HoleCards   []string         `json:"hole_cards,omitempty"`
+	HoleCards   []card.Card      `json:"hole_cards,omitempty"`
 	Combination *CombinationInfo `json:"combination,omitempty"`
+	ShownCards  []int            `json:"shown_cards,omitempty"` // indexes of hole cards shown voluntarily
 }
 
 type CombinationInfo struct {
-	Type  string   `json:"type"`
-	Cards []string `json:"cards"`
-	Power int      `json:"power"`
+	Type        string      `json:"type"`
+	Cards       []card.Card `json:"cards"`
+	Power       int         `json:"power"`
+	Description string      `json:"description"`
 }
 
-func (gs *GameState) AsPlayer(idx int) {
-
-	gs.Meta.Deck = []string{}
-	gs.Status.Burned = []string{}
-
-	// Do nothing if game has been closed already
-	if gs.Status.CurrentEvent == "GameClosed" {
+// Clone returns a deep copy of game state
+func (gs *GameState) Clone() *GameState {
 
-		for _, p := range gs.Players {
-			if p.Idx == idx {
-				continue
-			}
-
-			// Hide private information if player do fold
-			if p.Fold {
-				p.HoleCards = []string{}
-				p.Combination = nil
-			}
-		}
+	data, err := MarshalGameState(gs)
+	if err != nil {
+		return nil
+	}
 
-		return
+	state, err := UnmarshalGameState(data)
+	if err != nil {
+		return nil
 	}
 
-	for _, p := range gs.Players {
-		if p.Idx == idx {
-			continue
-		}
+	return state
+}
 
-		// Hide private information
-		p.HoleCards = []string{}
-		p.Combination = nil
-	}
+// AsPlayer hides private information from player in place, Redact is
+// preferred to keep the original state.
+func (gs *GameState) AsPlayer(idx int) {
+	gs.redact(idx, nil)
 }
 
+// AsObserver hides private information from observer in place, Redact is
+// preferred to keep the original state.
 func (gs *GameState) AsObserver() {
-
-	gs.Meta.Deck = []string{}
-	gs.Status.Burned = []string{}
-
-	if gs.Status.CurrentEvent == "GameClosed" {
-
-		for _, p := range gs.Players {
-
-			// Hide private information if player do fold
-			if p.Fold {
-				p.HoleCards = []string{}
-				p.Combination = nil
-			}
-		}
-
-		return
-	}
-
-	// Hide all private information
-	for _, p := range gs.Players {
-		p.HoleCards = []string{}
-		p.Combination = nil
-	}
+	gs.redact(ViewerObserver, nil)
 }
 
 func (gs *GameState) GetPlayer(idx int) *PlayerState {
@@ -0,0 +1,81 @@
+package mentalpoker
+
+import (
+	"crypto/rand"
+	"io"
+	"math/big"
+)
+
+// Party takes part in dealing, it could be a remote peer. A card is only
+// revealed after every party removed its layer of encryption.
+type Party interface {
+
+	// Shuffle encrypts every value with key of party then permutes them
+	Shuffle(deck []*big.Int) ([]*big.Int, error)
+
+	// Decrypt removes encryption layer of party
+	Decrypt(values []*big.Int) ([]*big.Int, error)
+}
+
+// LocalParty is a party in the same process
+type LocalParty struct {
+	key    *Key
+	random io.Reader
+}
+
+func NewLocalParty(g *Group) (*LocalParty, error) {
+
+	key, err := NewKey(g, rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LocalParty{
+		key:    key,
+		random: rand.Reader,
+	}, nil
+}
+
+func (lp *LocalParty) Shuffle(deck []*big.Int) ([]*big.Int, error) {
+
+	shuffled := make([]*big.Int, len(deck))
+	for i, v := range deck {
+
+		c, err := lp.key.Encrypt(v)
+		if err != nil {
+			return nil, err
+		}
+
+		shuffled[i] = c
+	}
+
+	// Fisher-Yates shuffle with cryptographic random source
+	for i := len(shuffled) - 1; i > 0; i-- {
+
+		j, err := rand.Int(lp.random, big.NewInt(int64(i+1)))
+		if err != nil {
+			return nil, err
+		}
+
+		k := j.Int64()
+		shuffled[i], shuffled[k] = shuffled[k], shuffled[i]
+	}
+
+	return shuffled, nil
+}
+
+func (lp *LocalParty) Decrypt(values []*big.Int) ([]*big.Int, error) {
+
+	decrypted := make([]*big.Int, len(values))
+	for i, v := range values {
+
+		m, err := lp.key.Decrypt(v)
+		if err != nil {
+			return nil, err
+		}
+
+		decrypted[i] = m
+	}
+
+	return decrypted, nil
+}
@@ -0,0 +1,218 @@
+package mentalpoker
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/weedbox/pokerface/card"
+)
+
+var (
+	ErrNoParty        = errors.New("mentalpoker: no party")
+	ErrNotShuffled    = errors.New("mentalpoker: deck is not shuffled")
+	ErrNotEnoughCards = errors.New("mentalpoker: not enough cards")
+	ErrInvalidDeck    = errors.New("mentalpoker: invalid deck")
+	ErrUnknownCard    = errors.New("mentalpoker: unknown card")
+	ErrUnknownParty   = errors.New("mentalpoker: unknown party")
+)
+
+// Session deals cards without a trusted dealer (SRA). Every party encrypts
+// and shuffles the deck in turn, so that no one knows the order of cards.
+// Cards are revealed only if all parties remove their encryption.
+//
+// Session implements pokerface.Dealing, cards dealt by Deal are revealed to
+// everyone, DealTo reveals cards to a single party.
+//
+// Session holds keys of all parties, so whoever runs it is a trusted party
+// which is able to see every card. Parties should run on their own side and
+// only exchange values of DealTo if no one is trusted.
+type Session struct {
+	group   *Group
+	parties []Party
+	cards   []card.Card
+	codes   map[string]card.Card
+	deck    []*big.Int
+	pos     int
+}
+
+func NewSession(g *Group, cards []card.Card, parties ...Party) *Session {
+
+	s := &Session{
+		group:   g,
+		parties: parties,
+		cards:   cards,
+		codes:   make(map[string]card.Card, len(cards)),
+	}
+
+	for i, c := range cards {
+		s.codes[g.Encode(i).String()] = c
+	}
+
+	return s
+}
+
+// Shuffle lets every party encrypt and shuffle the deck in turn
+func (s *Session) Shuffle() error {
+
+	if len(s.parties) == 0 {
+		return ErrNoParty
+	}
+
+	deck := make([]*big.Int, len(s.cards))
+	for i := range s.cards {
+		deck[i] = s.group.Encode(i)
+	}
+
+	for _, p := range s.parties {
+
+		shuffled, err := p.Shuffle(deck)
+		if err != nil {
+			return err
+		}
+
+		// Party must not drop or duplicate any card
+		if len(shuffled) != len(deck) || !distinct(shuffled) {
+			return ErrInvalidDeck
+		}
+
+		deck = shuffled
+	}
+
+	s.deck = deck
+	s.pos = 0
+
+	return nil
+}
+
+// Remaining returns the number of cards which are not dealt yet
+func (s *Session) Remaining() int {
+	return len(s.deck) - s.pos
+}
+
+func (s *Session) take(count int) ([]*big.Int, error) {
+
+	if s.deck == nil {
+		return nil, ErrNotShuffled
+	}
+
+	if count > s.Remaining() {
+		return nil, ErrNotEnoughCards
+	}
+
+	values := s.deck[s.pos : s.pos+count]
+	s.pos += count
+
+	return values, nil
+}
+
+// decrypt asks parties except the one to remove their encryption
+func (s *Session) decrypt(values []*big.Int, except int) ([]*big.Int, error) {
+
+	for i, p := range s.parties {
+
+		if i == except {
+			continue
+		}
+
+		decrypted, err := p.Decrypt(values)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(decrypted) != len(values) {
+			return nil, ErrInvalidDeck
+		}
+
+		values = decrypted
+	}
+
+	return values, nil
+}
+
+// Deal reveals cards to everyone with cooperation of all parties
+func (s *Session) Deal(count int) ([]card.Card, error) {
+
+	values, err := s.take(count)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err = s.decrypt(values, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Decode(values)
+}
+
+// Burn skips cards without revealing them
+func (s *Session) Burn(count int) error {
+	_, err := s.take(count)
+	return err
+}
+
+// DealTo returns cards which are still encrypted by the party only, the
+// party gets cards by Decrypt then Decode. Other parties learn nothing.
+func (s *Session) DealTo(party int, count int) ([]*big.Int, error) {
+
+	if party < 0 || party >= len(s.parties) {
+		return nil, ErrUnknownParty
+	}
+
+	values, err := s.take(count)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.decrypt(values, party)
+}
+
+// DealHoleCards deals cards to the party which removes the last layer
+// itself, so cards are never revealed to other parties. Cards are returned in
+// plain, which makes caller (e.g. game) a trusted party for hole cards.
+func (s *Session) DealHoleCards(party int, count int) ([]card.Card, error) {
+
+	values, err := s.DealTo(party, count)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err = s.parties[party].Decrypt(values)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Decode(values)
+}
+
+// Decode turns decrypted values into cards
+func (s *Session) Decode(values []*big.Int) ([]card.Card, error) {
+
+	cards := make([]card.Card, len(values))
+	for i, v := range values {
+
+		c, ok := s.codes[v.String()]
+		if !ok {
+			return nil, ErrUnknownCard
+		}
+
+		cards[i] = c
+	}
+
+	return cards, nil
+}
+
+func distinct(values []*big.Int) bool {
+
+	seen := make(map[string]bool, len(values))
+	for _, v := range values {
+
+		if v == nil || seen[v.String()] {
+			return false
+		}
+
+		seen[v.String()] = true
+	}
+
+	return true
+}
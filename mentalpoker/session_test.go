@@ -0,0 +1,202 @@
+package mentalpoker
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokerface/card"
+)
+
+func newTestDeck() []card.Card {
+
+	cards := make([]card.Card, 0, 52)
+	for _, s := range card.Suits {
+		for r := card.MinRank; r <= card.MaxRank; r++ {
+			c, _ := card.New(r, s)
+			cards = append(cards, c)
+		}
+	}
+
+	return cards
+}
+
+func newTestParties(t *testing.T, g *Group, count int) []Party {
+
+	parties := make([]Party, count)
+	for i := range parties {
+		p, err := NewLocalParty(g)
+		assert.Nil(t, err)
+		parties[i] = p
+	}
+
+	return parties
+}
+
+type cheatingParty struct {
+	Party
+	drop    bool
+	decline bool
+}
+
+func (cp *cheatingParty) Shuffle(deck []*big.Int) ([]*big.Int, error) {
+
+	shuffled, err := cp.Party.Shuffle(deck)
+	if err != nil || !cp.drop {
+		return shuffled, err
+	}
+
+	// Replace a card by a duplicate
+	shuffled[1] = shuffled[0]
+
+	return shuffled, nil
+}
+
+func (cp *cheatingParty) Decrypt(values []*big.Int) ([]*big.Int, error) {
+
+	if cp.decline {
+		return nil, errors.New("declined")
+	}
+
+	return cp.Party.Decrypt(values)
+}
+
+func TestKey_Commutative(t *testing.T) {
+
+	g := DefaultGroup()
+
+	a, err := NewKey(g, nil)
+	assert.Nil(t, err)
+	b, err := NewKey(g, nil)
+	assert.Nil(t, err)
+
+	m := g.Encode(7)
+
+	ab, _ := a.Encrypt(m)
+	ab, _ = b.Encrypt(ab)
+	assert.NotEqual(t, m, ab)
+
+	// Layers are removed in any order
+	v, _ := a.Decrypt(ab)
+	v, _ = b.Decrypt(v)
+	assert.Equal(t, 0, m.Cmp(v))
+
+	_, err = a.Encrypt(big.NewInt(0))
+	assert.Equal(t, ErrInvalidValue, err)
+
+	_, err = NewKey(&Group{P: big.NewInt(3)}, nil)
+	assert.Equal(t, ErrInvalidGroup, err)
+}
+
+func TestSession_Deal(t *testing.T) {
+
+	g := DefaultGroup()
+	deck := newTestDeck()
+	s := NewSession(g, deck, newTestParties(t, g, 3)...)
+
+	_, err := s.Deal(1)
+	assert.Equal(t, ErrNotShuffled, err)
+
+	assert.Nil(t, s.Shuffle())
+	assert.Equal(t, 52, s.Remaining())
+
+	assert.Nil(t, s.Burn(2))
+
+	cards, err := s.Deal(50)
+	assert.Nil(t, err)
+	assert.Equal(t, 50, card.NewCardSet(cards...).Count())
+	assert.NotEqual(t, deck[2:], cards)
+
+	_, err = s.Deal(1)
+	assert.Equal(t, ErrNotEnoughCards, err)
+}
+
+func TestSession_DealTo(t *testing.T) {
+
+	g := DefaultGroup()
+	parties := newTestParties(t, g, 3)
+	s := NewSession(g, newTestDeck(), parties...)
+	assert.Nil(t, s.Shuffle())
+
+	values, err := s.DealTo(1, 2)
+	assert.Nil(t, err)
+
+	// Cards are still encrypted by party 1
+	_, err = s.Decode(values)
+	assert.Equal(t, ErrUnknownCard, err)
+
+	// Other parties cannot reveal them
+	for _, i := range []int{0, 2} {
+		decrypted, err := parties[i].Decrypt(values)
+		assert.Nil(t, err)
+		_, err = s.Decode(decrypted)
+		assert.Equal(t, ErrUnknownCard, err)
+	}
+
+	decrypted, err := parties[1].Decrypt(values)
+	assert.Nil(t, err)
+	cards, err := s.Decode(decrypted)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, card.NewCardSet(cards...).Count())
+
+	_, err = s.DealTo(3, 1)
+	assert.Equal(t, ErrUnknownParty, err)
+}
+
+// spyParty keeps every value it has decrypted
+type spyParty struct {
+	Party
+	seen []*big.Int
+}
+
+func (sp *spyParty) Decrypt(values []*big.Int) ([]*big.Int, error) {
+
+	decrypted, err := sp.Party.Decrypt(values)
+	if err == nil {
+		sp.seen = append(sp.seen, decrypted...)
+	}
+
+	return decrypted, err
+}
+
+func TestSession_DealHoleCards(t *testing.T) {
+
+	g := DefaultGroup()
+	parties := newTestParties(t, g, 2)
+	spy := &spyParty{Party: parties[1]}
+
+	s := NewSession(g, newTestDeck(), parties[0], spy)
+	assert.Nil(t, s.Shuffle())
+
+	cards, err := s.DealHoleCards(0, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, card.NewCardSet(cards...).Count())
+
+	// Nothing which was seen by the other party is a card
+	assert.Equal(t, 2, len(spy.seen))
+	_, err = s.Decode(spy.seen)
+	assert.Equal(t, ErrUnknownCard, err)
+
+	_, err = s.DealHoleCards(2, 2)
+	assert.Equal(t, ErrUnknownParty, err)
+}
+
+func TestSession_Cheating(t *testing.T) {
+
+	g := DefaultGroup()
+	parties := newTestParties(t, g, 2)
+
+	// Duplicated card
+	s := NewSession(g, newTestDeck(), parties[0], &cheatingParty{Party: parties[1], drop: true})
+	assert.Equal(t, ErrInvalidDeck, s.Shuffle())
+
+	// Card cannot be revealed without cooperation
+	s = NewSession(g, newTestDeck(), parties[0], &cheatingParty{Party: parties[1], decline: true})
+	assert.Nil(t, s.Shuffle())
+	_, err := s.Deal(1)
+	assert.NotNil(t, err)
+
+	s = NewSession(g, newTestDeck())
+	assert.Equal(t, ErrNoParty, s.Shuffle())
+}
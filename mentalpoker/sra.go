@@ -0,0 +1,120 @@
+package mentalpoker
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+	"math/big"
+)
+
+var (
+	ErrInvalidGroup = errors.New("mentalpoker: invalid group")
+	ErrInvalidValue = errors.New("mentalpoker: invalid value")
+)
+
+// 2048-bit MODP group of RFC 3526, the prime is safe (p = 2q + 1)
+const modp2048 = "" +
+	"FFFFFFFFFFFFFFFFC90FDAA22168C234C4C6628B80DC1CD1" +
+	"29024E088A67CC74020BBEA63B139B22514A08798E3404DD" +
+	"EF9519B3CD3A431B302B0A6DF25F14374FE1356D6D51C245" +
+	"E485B576625E7EC6F44C42E9A637ED6B0BFF5CB6F406B7ED" +
+	"EE386BFB5A899FA5AE9F24117C4B1FE649286651ECE45B3D" +
+	"C2007CB8A163BF0598DA48361C55D39A69163FA8FD24CF5F" +
+	"83655D23DCA3AD961C62F356208552BB9ED529077096966D" +
+	"670C354E4ABC9804F1746C08CA18217C32905E462E36CE3B" +
+	"E39E772C180E86039B2783A2EC07A28FB5C55DF06F4C52C9" +
+	"DE2BCBF6955817183995497CEA956AE515D2261898FA0510" +
+	"15728E5A8AACAA68FFFFFFFFFFFFFFFF"
+
+// Group is the prime field which all parties encrypt cards in
+type Group struct {
+	P *big.Int
+}
+
+// DefaultGroup returns 2048-bit MODP group
+func DefaultGroup() *Group {
+
+	p, _ := new(big.Int).SetString(modp2048, 16)
+
+	return &Group{
+		P: p,
+	}
+}
+
+// Encode turns index of card into a quadratic residue, so that encryption
+// leaks nothing about quadratic residuosity of cards.
+func (g *Group) Encode(idx int) *big.Int {
+	x := big.NewInt(int64(idx) + 2)
+	return x.Exp(x, big.NewInt(2), g.P)
+}
+
+func (g *Group) valid(v *big.Int) bool {
+	return v != nil && v.Sign() > 0 && v.Cmp(g.P) < 0
+}
+
+// Key is a SRA key pair, encryption is commutative between keys of the same
+// group: D_a(E_b(E_a(m))) = E_b(m).
+type Key struct {
+	group *Group
+	e     *big.Int
+	d     *big.Int
+}
+
+// NewKey generates key with random source which is crypto/rand if nil
+func NewKey(g *Group, random io.Reader) (*Key, error) {
+
+	if g == nil || g.P == nil || g.P.Cmp(big.NewInt(3)) <= 0 {
+		return nil, ErrInvalidGroup
+	}
+
+	if random == nil {
+		random = rand.Reader
+	}
+
+	phi := new(big.Int).Sub(g.P, big.NewInt(1))
+	one := big.NewInt(1)
+
+	for {
+
+		e, err := rand.Int(random, phi)
+		if err != nil {
+			return nil, err
+		}
+
+		if e.Cmp(big.NewInt(3)) < 0 {
+			continue
+		}
+
+		// Exponent must be invertible modulo p-1
+		d := new(big.Int)
+		if new(big.Int).GCD(d, nil, e, phi).Cmp(one) != 0 {
+			continue
+		}
+
+		d.Mod(d, phi)
+
+		return &Key{
+			group: g,
+			e:     e,
+			d:     d,
+		}, nil
+	}
+}
+
+func (k *Key) Encrypt(v *big.Int) (*big.Int, error) {
+
+	if !k.group.valid(v) {
+		return nil, ErrInvalidValue
+	}
+
+	return new(big.Int).Exp(v, k.e, k.group.P), nil
+}
+
+func (k *Key) Decrypt(v *big.Int) (*big.Int, error) {
+
+	if !k.group.valid(v) {
+		return nil, ErrInvalidValue
+	}
+
+	return new(big.Int).Exp(v, k.d, k.group.P), nil
+}
@@ -0,0 +1,97 @@
+package pokerface
+
+import (
+	"errors"
+
+	"github.com/weedbox/pokerface/pot"
+	"github.com/weedbox/pokerface/settlement"
+)
+
+var (
+	ErrMisdealNotAllowed = errors.New("game: misdeal is not allowed")
+)
+
+// Limits of misdeal, hand cannot be voided after significant action
+const (
+	MisdealBeforeAction   = "action"  // no voluntary action yet, forced bets only
+	MisdealBeforeFlop     = "preflop" // any time before flop was dealt
+	MisdealBeforeShowdown = "any"     // any time before settlement
+)
+
+// Misdeal voids the current hand, all wagers, antes and blinds are returned
+// to bankroll. Positions are untouched, so the hand could be restarted with
+// the same button.
+func (g *game) Misdeal(reason string) error {
+
+	if !g.isMisdealAllowed() {
+		return ErrMisdealNotAllowed
+	}
+
+	// Refund
+	for _, ps := range g.gs.Players {
+		ps.AllowedActions = make([]string, 0)
+		ps.Pot = 0
+		ps.Wager = 0
+		ps.DeadBlind = 0
+		ps.InitialStackSize = ps.Bankroll
+		ps.StackSize = ps.Bankroll
+	}
+
+	g.gs.Status.Pots = make([]*pot.Pot, 0)
+	g.gs.Status.CurrentRoundPot = 0
+	g.gs.Status.CurrentWager = 0
+	g.gs.Status.CardsRequest = nil
+	g.gs.Status.VoidReason = reason
+
+	// Nobody wins or loses
+	r := settlement.NewResult()
+	for _, ps := range g.gs.Players {
+		r.AddPlayer(ps.Idx, ps.Bankroll)
+	}
+
+	g.gs.Result = r
+
+	return g.EmitEvent(GameEvent_GameVoided)
+}
+
+func (g *game) isMisdealAllowed() bool {
+
+	switch g.gs.Status.CurrentEvent {
+	case "", "GameCompleted", "SettlementRequested", "SettlementCompleted", "GameClosed", "GameVoided":
+		return false
+	}
+
+	switch g.gs.Meta.MisdealLimit {
+	case MisdealBeforeShowdown:
+		return true
+	case MisdealBeforeFlop:
+		return g.gs.Status.Round == "" || g.gs.Status.Round == "preflop"
+	}
+
+	return !g.hasVoluntaryAction()
+}
+
+// hasVoluntaryAction returns true if any player acted by choice, paying ante
+// and blinds is not counted.
+func (g *game) hasVoluntaryAction() bool {
+
+	switch g.gs.Status.Round {
+	case "":
+		return false
+	case "preflop":
+	default:
+		return true
+	}
+
+	la := g.gs.Status.LastAction
+	if la == nil {
+		return false
+	}
+
+	switch la.Type {
+	case "fold", "call", "check", "bet", "raise", "allin":
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,443 @@
+package patch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidPath = errors.New("patch: invalid path")
+	ErrUnknownOp   = errors.New("patch: unknown operation")
+)
+
+const (
+	OpAdd     = "add"
+	OpRemove  = "remove"
+	OpReplace = "replace"
+)
+
+// Op is an operation of JSON Patch (RFC 6902), only add, remove and replace
+// are supported. Path is JSON Pointer (RFC 6901).
+type Op struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// ToDocument turns value into generic JSON document
+func ToDocument(v interface{}) (interface{}, error) {
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc interface{}
+	err = json.Unmarshal(data, &doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// Diff returns operations which turn from into to, both of them are
+// marshaled to JSON before comparison.
+func Diff(from interface{}, to interface{}) ([]Op, error) {
+
+	a, err := ToDocument(from)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := ToDocument(to)
+	if err != nil {
+		return nil, err
+	}
+
+	return DiffDocuments(a, b), nil
+}
+
+// DiffDocuments returns operations between generic JSON documents
+func DiffDocuments(from interface{}, to interface{}) []Op {
+
+	ops := make([]Op, 0)
+	diff("", from, to, &ops)
+
+	return ops
+}
+
+func diff(path string, a interface{}, b interface{}, ops *[]Op) {
+
+	switch a.(type) {
+	case map[string]interface{}, []interface{}:
+
+		children := make([]Op, 0)
+		diffChildren(path, a, b, &children)
+
+		// Replacing the whole value is smaller than operations of children
+		if len(children) > 1 {
+			replacement := []Op{
+				{Op: OpReplace, Path: path, Value: b},
+			}
+
+			if size(replacement) < size(children) {
+				children = replacement
+			}
+		}
+
+		*ops = append(*ops, children...)
+
+		return
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		*ops = append(*ops, Op{Op: OpReplace, Path: path, Value: b})
+	}
+}
+
+func size(ops []Op) int {
+	data, _ := json.Marshal(ops)
+	return len(data)
+}
+
+func diffChildren(path string, a interface{}, b interface{}, ops *[]Op) {
+
+	switch av := a.(type) {
+	case map[string]interface{}:
+
+		bv, ok := b.(map[string]interface{})
+		if !ok {
+			break
+		}
+
+		// Keys are sorted to make sure output is always the same
+		keys := make([]string, 0, len(av))
+		for k := range av {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+
+			child := path + "/" + escape(k)
+
+			v, ok := bv[k]
+			if !ok {
+				*ops = append(*ops, Op{Op: OpRemove, Path: child})
+				continue
+			}
+
+			diff(child, av[k], v, ops)
+		}
+
+		keys = keys[:0]
+		for k := range bv {
+			if _, ok := av[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			*ops = append(*ops, Op{Op: OpAdd, Path: path + "/" + escape(k), Value: bv[k]})
+		}
+
+		return
+
+	case []interface{}:
+
+		bv, ok := b.([]interface{})
+		if !ok {
+			break
+		}
+
+		common := len(av)
+		if len(bv) < common {
+			common = len(bv)
+		}
+
+		for i := 0; i < common; i++ {
+			diff(path+"/"+strconv.Itoa(i), av[i], bv[i], ops)
+		}
+
+		// Appended elements
+		for i := common; i < len(bv); i++ {
+			*ops = append(*ops, Op{Op: OpAdd, Path: path + "/" + strconv.Itoa(i), Value: bv[i]})
+		}
+
+		// Removed elements from the end
+		for i := len(av) - 1; i >= common; i-- {
+			*ops = append(*ops, Op{Op: OpRemove, Path: path + "/" + strconv.Itoa(i)})
+		}
+
+		return
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		*ops = append(*ops, Op{Op: OpReplace, Path: path, Value: b})
+	}
+}
+
+// Apply applies operations to generic JSON document and returns the new
+// document. The original document is not modified.
+func Apply(doc interface{}, ops []Op) (interface{}, error) {
+
+	doc = deepCopy(doc)
+
+	for _, op := range ops {
+
+		var err error
+		doc, err = apply(doc, op)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s %q", err, op.Op, op.Path)
+		}
+	}
+
+	return doc, nil
+}
+
+func apply(doc interface{}, op Op) (interface{}, error) {
+
+	if len(op.Path) == 0 {
+
+		switch op.Op {
+		case OpAdd, OpReplace:
+			return deepCopy(op.Value), nil
+		case OpRemove:
+			return nil, nil
+		}
+
+		return nil, ErrUnknownOp
+	}
+
+	if op.Path[0] != '/' {
+		return nil, ErrInvalidPath
+	}
+
+	tokens := strings.Split(op.Path[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = unescape(t)
+	}
+
+	// Find parent of target
+	parent, err := resolve(doc, tokens[:len(tokens)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	key := tokens[len(tokens)-1]
+	value := deepCopy(op.Value)
+
+	switch p := parent.(type) {
+	case map[string]interface{}:
+
+		_, exists := p[key]
+
+		switch op.Op {
+		case OpAdd:
+			p[key] = value
+		case OpReplace:
+			if !exists {
+				return nil, ErrInvalidPath
+			}
+			p[key] = value
+		case OpRemove:
+			if !exists {
+				return nil, ErrInvalidPath
+			}
+			delete(p, key)
+		default:
+			return nil, ErrUnknownOp
+		}
+
+		return doc, nil
+
+	case []interface{}:
+
+		idx, err := strconv.Atoi(key)
+		if key == "-" {
+			idx, err = len(p), nil
+		}
+
+		if err != nil || idx < 0 || idx > len(p) || (op.Op != OpAdd && idx == len(p)) {
+			return nil, ErrInvalidPath
+		}
+
+		var updated []interface{}
+		switch op.Op {
+		case OpAdd:
+			updated = append(p[:idx:idx], append([]interface{}{value}, p[idx:]...)...)
+		case OpReplace:
+			p[idx] = value
+			return doc, nil
+		case OpRemove:
+			updated = append(p[:idx:idx], p[idx+1:]...)
+		default:
+			return nil, ErrUnknownOp
+		}
+
+		// Slice header is changed so that parent of array should be updated
+		return replace(doc, tokens[:len(tokens)-1], updated)
+	}
+
+	return nil, ErrInvalidPath
+}
+
+func resolve(doc interface{}, tokens []string) (interface{}, error) {
+
+	cur := doc
+	for _, t := range tokens {
+
+		switch v := cur.(type) {
+		case map[string]interface{}:
+
+			child, ok := v[t]
+			if !ok {
+				return nil, ErrInvalidPath
+			}
+
+			cur = child
+
+		case []interface{}:
+
+			idx, err := strconv.Atoi(t)
+			if err != nil || idx < 0 || idx >= len(v) {
+				return nil, ErrInvalidPath
+			}
+
+			cur = v[idx]
+
+		default:
+			return nil, ErrInvalidPath
+		}
+	}
+
+	return cur, nil
+}
+
+func replace(doc interface{}, tokens []string, value interface{}) (interface{}, error) {
+
+	if len(tokens) == 0 {
+		return value, nil
+	}
+
+	parent, err := resolve(doc, tokens[:len(tokens)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	key := tokens[len(tokens)-1]
+	switch p := parent.(type) {
+	case map[string]interface{}:
+		p[key] = value
+	case []interface{}:
+		idx, _ := strconv.Atoi(key)
+		p[idx] = value
+	}
+
+	return doc, nil
+}
+
+// overlay returns a copy of document with value at path, only containers
+// along the path are copied and the rest is shared with the original.
+func overlay(doc interface{}, path string, value interface{}) (interface{}, error) {
+
+	if len(path) == 0 || path[0] != '/' {
+		return nil, ErrInvalidPath
+	}
+
+	tokens := strings.Split(path[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = unescape(t)
+	}
+
+	return overlayTokens(doc, tokens, value)
+}
+
+func overlayTokens(doc interface{}, tokens []string, value interface{}) (interface{}, error) {
+
+	if len(tokens) == 0 {
+		return value, nil
+	}
+
+	switch v := doc.(type) {
+	case map[string]interface{}:
+
+		child, ok := v[tokens[0]]
+		if !ok {
+			return nil, ErrInvalidPath
+		}
+
+		child, err := overlayTokens(child, tokens[1:], value)
+		if err != nil {
+			return nil, err
+		}
+
+		m := make(map[string]interface{}, len(v))
+		for k, c := range v {
+			m[k] = c
+		}
+		m[tokens[0]] = child
+
+		return m, nil
+
+	case []interface{}:
+
+		idx, err := strconv.Atoi(tokens[0])
+		if err != nil || idx < 0 || idx >= len(v) {
+			return nil, ErrInvalidPath
+		}
+
+		child, err := overlayTokens(v[idx], tokens[1:], value)
+		if err != nil {
+			return nil, err
+		}
+
+		s := make([]interface{}, len(v))
+		copy(s, v)
+		s[idx] = child
+
+		return s, nil
+	}
+
+	return nil, ErrInvalidPath
+}
+
+func deepCopy(v interface{}) interface{} {
+
+	switch vv := v.(type) {
+	case map[string]interface{}:
+
+		m := make(map[string]interface{}, len(vv))
+		for k, child := range vv {
+			m[k] = deepCopy(child)
+		}
+
+		return m
+
+	case []interface{}:
+
+		s := make([]interface{}, len(vv))
+		for i, child := range vv {
+			s[i] = deepCopy(child)
+		}
+
+		return s
+	}
+
+	return v
+}
+
+func escape(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+func unescape(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+}
@@ -0,0 +1,250 @@
+package patch
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokerface"
+)
+
+func playGame(t *testing.T) []*pokerface.GameState {
+
+	pf := pokerface.NewPokerFace()
+
+	opts := pokerface.NewStardardGameOptions()
+	opts.Deck = pokerface.NewStandardDeckCards()
+	opts.Players = append(opts.Players,
+		&pokerface.PlayerSetting{Bankroll: 10000, Positions: []string{"dealer"}},
+		&pokerface.PlayerSetting{Bankroll: 10000, Positions: []string{"sb"}},
+		&pokerface.PlayerSetting{Bankroll: 10000, Positions: []string{"bb"}},
+	)
+
+	g := pf.NewGame(opts)
+	assert.Nil(t, g.Start())
+
+	states := make([]*pokerface.GameState, 0)
+	snapshot := func() {
+		data, _ := g.GetStateJSON()
+		var gs pokerface.GameState
+		json.Unmarshal(data, &gs)
+		states = append(states, &gs)
+	}
+
+	snapshot()
+
+	for g.GetState().Status.CurrentEvent != "GameClosed" {
+
+		switch g.GetState().Status.CurrentEvent {
+		case "ReadyRequested":
+			assert.Nil(t, g.ReadyForAll())
+		case "BlindsRequested":
+			assert.Nil(t, g.PayBlinds())
+		case "RoundClosed":
+			assert.Nil(t, g.Next())
+		case "RoundStarted":
+			if g.GetCurrentPlayer().CheckAction("check") {
+				assert.Nil(t, g.Check())
+			} else {
+				assert.Nil(t, g.Call())
+			}
+		default:
+			t.Fatalf("unexpected event: %s", g.GetState().Status.CurrentEvent)
+		}
+
+		snapshot()
+	}
+
+	return states
+}
+
+func TestDiff_Apply(t *testing.T) {
+
+	states := playGame(t)
+	for i := 1; i < len(states); i++ {
+
+		from, _ := ToDocument(states[i-1])
+		to, _ := ToDocument(states[i])
+
+		ops, err := Diff(states[i-1], states[i])
+		assert.Nil(t, err)
+
+		result, err := Apply(from, ops)
+		assert.Nil(t, err)
+		assert.Equal(t, to, result)
+
+		// Original document is not modified
+		original, _ := ToDocument(states[i-1])
+		assert.Equal(t, original, from)
+
+		// Patch is smaller than full state
+		full, _ := json.Marshal(states[i])
+		data, _ := json.Marshal(ops)
+		assert.Less(t, len(data), len(full))
+	}
+}
+
+func TestDiff_Operations(t *testing.T) {
+
+	from := map[string]interface{}{
+		"a/b":   1,
+		"board": []string{"SA", "SK"},
+		"deck":  []string{"S2", "S3", "S4"},
+		"gone":  true,
+		"large": strings.Repeat("x", 256),
+	}
+
+	to := map[string]interface{}{
+		"a/b":   2,
+		"board": []string{"SA", "SK", "SQ"},
+		"deck":  []string{"S2"},
+		"new":   "x",
+		"large": strings.Repeat("x", 256),
+	}
+
+	ops, err := Diff(from, to)
+	assert.Nil(t, err)
+	assert.Equal(t, []Op{
+		{Op: OpReplace, Path: "/a~1b", Value: float64(2)},
+		{Op: OpAdd, Path: "/board/2", Value: "SQ"},
+		{Op: OpReplace, Path: "/deck", Value: []interface{}{"S2"}},
+		{Op: OpRemove, Path: "/gone"},
+		{Op: OpAdd, Path: "/new", Value: "x"},
+	}, ops)
+
+	doc, _ := ToDocument(from)
+	result, err := Apply(doc, ops)
+	assert.Nil(t, err)
+
+	expected, _ := ToDocument(to)
+	assert.Equal(t, expected, result)
+
+	// Elements of arrays
+	result, err = Apply(doc, []Op{
+		{Op: OpRemove, Path: "/deck/0"},
+		{Op: OpAdd, Path: "/board/-", Value: "SJ"},
+		{Op: OpAdd, Path: "/board/0", Value: "ST"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"S3", "S4"}, result.(map[string]interface{})["deck"])
+	assert.Equal(t, []interface{}{"ST", "SA", "SK", "SJ"}, result.(map[string]interface{})["board"])
+
+	// Invalid operations
+	_, err = Apply(doc, []Op{{Op: OpReplace, Path: "/unknown/0", Value: 1}})
+	assert.ErrorIs(t, err, ErrInvalidPath)
+
+	_, err = Apply(doc, []Op{{Op: "move", Path: "/gone"}})
+	assert.ErrorIs(t, err, ErrUnknownOp)
+
+	// The whole value is replaced if it is smaller
+	ops, err = Diff([]int{1, 2, 3, 4}, []int{5, 6, 7, 8})
+	assert.Nil(t, err)
+	assert.Equal(t, []Op{{Op: OpReplace, Path: "", Value: []interface{}{float64(5), float64(6), float64(7), float64(8)}}}, ops)
+}
+
+func TestGenerator_Applier(t *testing.T) {
+
+	states := playGame(t)
+
+	g := NewGenerator()
+	a := NewApplier()
+
+	patches := make([]*Patch, 0)
+	for _, gs := range states {
+		p, err := g.Next(gs)
+		assert.Nil(t, err)
+		patches = append(patches, p)
+	}
+
+	assert.Equal(t, uint64(len(states)), g.Seq())
+
+	for i, p := range patches {
+
+		// Duplicate patches are ignored
+		assert.Nil(t, a.Apply(p))
+		assert.Nil(t, a.Apply(p))
+		assert.Equal(t, p.Seq, a.Seq())
+
+		var gs pokerface.GameState
+		assert.Nil(t, a.Decode(&gs))
+
+		expected, _ := json.Marshal(states[i])
+		actual, _ := json.Marshal(&gs)
+		assert.JSONEq(t, string(expected), string(actual))
+	}
+
+	// Client missed patches
+	a = NewApplier()
+	assert.Nil(t, a.Apply(patches[0]))
+	assert.Equal(t, ErrSequenceGap, a.Apply(patches[2]))
+
+	// Resync with snapshot
+	assert.Nil(t, a.Reset(g.Snapshot()))
+	assert.Equal(t, g.Seq(), a.Seq())
+
+	data, err := a.Document()
+	assert.Nil(t, err)
+	expected, _ := json.Marshal(states[len(states)-1])
+	assert.JSONEq(t, string(expected), string(data))
+}
+
+func TestBroadcaster_Applier(t *testing.T) {
+
+	states := playGame(t)
+
+	b := NewBroadcaster()
+	appliers := map[string]*Applier{
+		"":   NewApplier(),
+		"p0": NewApplier(),
+		"p1": NewApplier(),
+	}
+
+	for _, gs := range states {
+
+		// Players see their own cards on top of state of observers
+		viewers := map[string]*Private{
+			"": nil,
+		}
+
+		for i, id := range []string{"p0", "p1"} {
+			viewers[id] = &Private{
+				Path:  "/players/" + strconv.Itoa(i),
+				Value: gs.Players[i],
+			}
+		}
+
+		patches, err := b.Next(gs.Redact(-1, nil), viewers)
+		assert.Nil(t, err)
+		assert.Equal(t, len(viewers), len(patches))
+
+		for id, a := range appliers {
+
+			assert.Nil(t, a.Apply(patches[id]))
+
+			viewer := -1
+			if id != "" {
+				viewer = viewers[id].Value.(*pokerface.PlayerState).Idx
+			}
+
+			data, err := a.Document()
+			assert.Nil(t, err)
+			expected, _ := json.Marshal(gs.Redact(viewer, nil))
+			assert.JSONEq(t, string(expected), string(data))
+		}
+	}
+
+	// Resync with snapshot
+	s, err := b.Snapshot("p1")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(len(states)), s.Seq)
+	assert.Equal(t, b.Seq("p1"), s.Seq)
+
+	// Viewers who are gone are dropped
+	_, err = b.Next(states[0], map[string]*Private{"": nil})
+	assert.Nil(t, err)
+
+	_, err = b.Snapshot("p1")
+	assert.Equal(t, ErrUnknownViewer, err)
+}
@@ -0,0 +1,312 @@
+package patch
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrSequenceGap   = errors.New("patch: sequence gap, full snapshot is required")
+	ErrUnknownViewer = errors.New("patch: unknown viewer")
+)
+
+// Patch turns state of sequence Seq-1 into state of sequence Seq
+type Patch struct {
+	Seq uint64 `json:"seq"`
+	Ops []Op   `json:"ops"`
+}
+
+// Snapshot is the full state of a sequence
+type Snapshot struct {
+	Seq   uint64          `json:"seq"`
+	State json.RawMessage `json:"state"`
+}
+
+// Generator produces patches for a series of states on server side
+type Generator struct {
+	mu  sync.RWMutex
+	seq uint64
+	doc interface{}
+}
+
+func NewGenerator() *Generator {
+	return &Generator{}
+}
+
+// Next returns patch from the previous state to the new one
+func (g *Generator) Next(state interface{}) (*Patch, error) {
+
+	doc, err := ToDocument(state)
+	if err != nil {
+		return nil, err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ops := DiffDocuments(g.doc, doc)
+	if g.seq == 0 {
+		// The first patch always has the whole state
+		ops = []Op{
+			{Op: OpReplace, Path: "", Value: doc},
+		}
+	}
+
+	g.seq++
+	g.doc = doc
+
+	return &Patch{
+		Seq: g.seq,
+		Ops: ops,
+	}, nil
+}
+
+func (g *Generator) Seq() uint64 {
+
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.seq
+}
+
+// Snapshot returns the latest state for clients to resync
+func (g *Generator) Snapshot() *Snapshot {
+
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	data, _ := json.Marshal(g.doc)
+
+	return &Snapshot{
+		Seq:   g.seq,
+		State: data,
+	}
+}
+
+// Private is the part of state which is visible to one viewer only, it
+// replaces value at Path of the shared state.
+type Private struct {
+	Path  string
+	Value interface{}
+}
+
+type view struct {
+	seq     uint64
+	doc     interface{}
+	private bool
+	path    string
+	value   interface{}
+}
+
+// Broadcaster produces patches of a state for many viewers. Viewers see the
+// same state except their private parts, so that the state is converted and
+// diffed only once and operations are shared by viewers.
+type Broadcaster struct {
+	mu    sync.RWMutex
+	base  interface{}
+	views map[string]*view
+}
+
+func NewBroadcaster() *Broadcaster {
+	return &Broadcaster{
+		views: make(map[string]*view),
+	}
+}
+
+// Next returns patches of viewers from their previous states to the new one,
+// viewer without private part sees state as it is. Viewers who are not given
+// are dropped.
+func (b *Broadcaster) Next(state interface{}, viewers map[string]*Private) (map[string]*Patch, error) {
+
+	base, err := ToDocument(state)
+	if err != nil {
+		return nil, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	shared := DiffDocuments(b.base, base)
+
+	views := make(map[string]*view, len(viewers))
+	patches := make(map[string]*Patch, len(viewers))
+	for id, private := range viewers {
+
+		v := &view{
+			doc: base,
+		}
+
+		if private != nil {
+
+			v.private = true
+			v.path = private.Path
+
+			v.value, err = ToDocument(private.Value)
+			if err != nil {
+				return nil, err
+			}
+
+			v.doc, err = overlay(base, private.Path, v.value)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		var ops []Op
+		prev, ok := b.views[id]
+		switch {
+		case !ok:
+			// The first patch always has the whole state
+			ops = []Op{
+				{Op: OpReplace, Path: "", Value: v.doc},
+			}
+		case prev.private != v.private || prev.path != v.path || (v.private && overwrites(shared, v.path)):
+			ops = DiffDocuments(prev.doc, v.doc)
+		case !v.private:
+			ops = shared
+		default:
+			ops = make([]Op, 0, len(shared))
+			for _, op := range shared {
+				if op.Path != v.path && !strings.HasPrefix(op.Path, v.path+"/") {
+					ops = append(ops, op)
+				}
+			}
+
+			diff(v.path, prev.value, v.value, &ops)
+		}
+
+		if ok {
+			v.seq = prev.seq
+		}
+
+		v.seq++
+		views[id] = v
+		patches[id] = &Patch{
+			Seq: v.seq,
+			Ops: ops,
+		}
+	}
+
+	b.base = base
+	b.views = views
+
+	return patches, nil
+}
+
+// overwrites returns true if any of operations replaces ancestor of path
+func overwrites(ops []Op, path string) bool {
+
+	for _, op := range ops {
+		if op.Path == "" || strings.HasPrefix(path, op.Path+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Seq returns sequence of viewer, it is zero for unknown viewer
+func (b *Broadcaster) Seq(viewer string) uint64 {
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	v, ok := b.views[viewer]
+	if !ok {
+		return 0
+	}
+
+	return v.seq
+}
+
+// Snapshot returns the latest state of viewer for clients to resync
+func (b *Broadcaster) Snapshot(viewer string) (*Snapshot, error) {
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	v, ok := b.views[viewer]
+	if !ok {
+		return nil, ErrUnknownViewer
+	}
+
+	data, err := json.Marshal(v.doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Snapshot{
+		Seq:   v.seq,
+		State: data,
+	}, nil
+}
+
+// Applier applies patches on client side
+type Applier struct {
+	seq uint64
+	doc interface{}
+}
+
+func NewApplier() *Applier {
+	return &Applier{}
+}
+
+// Reset replaces state with snapshot
+func (a *Applier) Reset(s *Snapshot) error {
+
+	var doc interface{}
+	err := json.Unmarshal(s.State, &doc)
+	if err != nil {
+		return err
+	}
+
+	a.seq = s.Seq
+	a.doc = doc
+
+	return nil
+}
+
+// Apply applies patch to state. Patches which were applied already are
+// ignored, ErrSequenceGap is returned if any patch was missed.
+func (a *Applier) Apply(p *Patch) error {
+
+	if p.Seq <= a.seq {
+		return nil
+	}
+
+	if p.Seq != a.seq+1 {
+		return ErrSequenceGap
+	}
+
+	doc, err := Apply(a.doc, p.Ops)
+	if err != nil {
+		return err
+	}
+
+	a.seq = p.Seq
+	a.doc = doc
+
+	return nil
+}
+
+func (a *Applier) Seq() uint64 {
+	return a.seq
+}
+
+// Document returns state in JSON
+func (a *Applier) Document() ([]byte, error) {
+	return json.Marshal(a.doc)
+}
+
+// Decode decodes state into v
+func (a *Applier) Decode(v interface{}) error {
+
+	data, err := a.Document()
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
@@ -7,6 +7,8 @@ import (
 var (
 	ErrInvalidAction = errors.New("player: invalid action")
 	ErrIllegalRaise  = errors.New("player: illegal raise")
+	ErrIllegalBet    = errors.New("player: illegal bet")
+	ErrInvalidCard   = errors.New("player: invalid hole card")
 )
 
 type Player interface {
@@ -27,6 +29,7 @@ type Player interface {
 	Allin() error
 	Bet(chips int64) error
 	Raise(chipLevel int64) error
+	Show(cards ...int) error
 }
 
 type player struct {
@@ -220,6 +223,11 @@ func (p *player) PayBlinds() error {
 	} else if gs.Meta.Blind.Dealer > 0 && p.CheckPosition("dealer") {
 		chips = gs.Meta.Blind.Dealer
 		action = "dealer_blind"
+	} else if gs.Meta.Blind.BB > 0 && p.CheckPosition("post") {
+
+		// Player who missed blinds posts a live big blind
+		chips = gs.Meta.Blind.BB
+		action = "posted_blind"
 	}
 
 	if p.State().StackSize < chips {
@@ -233,6 +241,23 @@ func (p *player) PayBlinds() error {
 
 	p.game.UpdateLastAction(p.idx, action, chips)
 
+	// Small blind which was missed is dead, it goes to main pot without
+	// raising contribution of player
+	if gs.Meta.Blind.SB > 0 && p.CheckPosition("dead_sb") && p.state.StackSize > 0 {
+
+		dead := gs.Meta.Blind.SB
+		if p.state.StackSize <= dead {
+			dead = p.state.StackSize
+			p.state.DidAction = "allin"
+		}
+
+		p.state.DeadBlind += dead
+		p.state.InitialStackSize -= dead
+		p.state.StackSize -= dead
+
+		p.game.UpdateLastAction(p.idx, "dead_blind", dead)
+	}
+
 	return nil
 }
 
@@ -286,6 +311,40 @@ func (p *player) Fold() error {
 	return p.game.Resume()
 }
 
+// Show reveals hole cards voluntarily, all of hole cards are shown if no
+// index is specified.
+func (p *player) Show(cards ...int) error {
+
+	if len(cards) == 0 {
+		for i := range p.state.HoleCards {
+			cards = append(cards, i)
+		}
+	}
+
+	for _, idx := range cards {
+		if idx < 0 || idx >= len(p.state.HoleCards) {
+			return ErrInvalidCard
+		}
+	}
+
+	for _, idx := range cards {
+
+		shown := false
+		for _, s := range p.state.ShownCards {
+			if s == idx {
+				shown = true
+				break
+			}
+		}
+
+		if !shown {
+			p.state.ShownCards = append(p.state.ShownCards, idx)
+		}
+	}
+
+	return nil
+}
+
 func (p *player) Call() error {
 
 	if !p.CheckAction("call") {
@@ -304,7 +363,10 @@ func (p *player) Call() error {
 	p.state.DidAction = "call"
 	p.state.Acted = true
 
-	p.pay(delta, true)
+	err := p.pay(delta, true)
+	if err != nil {
+		return err
+	}
 
 	p.game.UpdateLastAction(p.idx, "call", delta)
 
@@ -333,14 +395,23 @@ func (p *player) Bet(chips int64) error {
 		return ErrInvalidAction
 	}
 
+	// Player cannot bet more than stack
+	gs := p.game.GetState()
+	if chips <= 0 || chips > p.state.StackSize {
+		return ErrIllegalBet
+	}
+
 	//fmt.Printf("[Player %d] bet %d\n", p.idx, chips)
 
 	p.state.DidAction = "bet"
 	p.state.Acted = true
 
-	p.pay(chips, true)
+	err := p.pay(chips, true)
+	if err != nil {
+		return err
+	}
 
-	p.game.GetState().Status.PreviousRaiseSize = chips
+	gs.Status.PreviousRaiseSize = chips
 
 	p.game.UpdateLastAction(p.idx, "bet", chips)
 
@@ -354,7 +425,7 @@ func (p *player) Raise(chipLevel int64) error {
 	}
 
 	gs := p.game.GetState()
-	if chipLevel == 0 || chipLevel < gs.Status.CurrentWager {
+	if chipLevel == 0 || chipLevel < gs.Status.CurrentWager || chipLevel > p.state.InitialStackSize {
 		return ErrIllegalRaise
 	}
 
@@ -367,10 +438,14 @@ func (p *player) Raise(chipLevel int64) error {
 	required := chipLevel - p.state.Wager
 	//fmt.Println(gs.Status.PreviousRaiseSize)
 	//fmt.Printf(" %d => initial=%d, raised=%d, required=%d\n", chipLevel, p.state.InitialStackSize, raised, required)
-	if chipLevel >= p.state.InitialStackSize || raised < gs.Status.PreviousRaiseSize {
+	if chipLevel == p.state.InitialStackSize {
 		return p.Allin()
 	}
 
+	if raised < gs.Status.PreviousRaiseSize {
+		return ErrIllegalRaise
+	}
+
 	// Check if raising rule is pot limit
 	if gs.Meta.Limit == "pot" {
 		maxRaise := gs.Status.CurrentWager + gs.Status.PreviousRaiseSize
@@ -388,7 +463,10 @@ func (p *player) Raise(chipLevel int64) error {
 	// Update raise size
 	gs.Status.PreviousRaiseSize = raised
 
-	p.pay(required, true)
+	err := p.pay(required, true)
+	if err != nil {
+		return err
+	}
 
 	p.game.UpdateLastAction(p.idx, "raise", required)
 
@@ -414,7 +492,10 @@ func (p *player) Allin() error {
 		gs.Status.PreviousRaiseSize = raised
 	}
 
-	p.pay(p.state.StackSize, true)
+	err := p.pay(p.state.StackSize, true)
+	if err != nil {
+		return err
+	}
 
 	p.game.UpdateLastAction(p.idx, "allin", p.state.InitialStackSize)
 
@@ -1,6 +1,7 @@
 package pokerface
 
 import (
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
@@ -9,17 +10,47 @@ import (
 type PokerFace interface {
 	NewGame(opts *GameOptions) Game
 	NewGameFromState(gs *GameState) Game
+	LoadGame(gs *GameState) (Game, error)
 }
 
+type PokerFaceOpt func(*pokerface)
+
 type pokerface struct {
+	store   SecretStore
+	auditor AuditHandler
+}
+
+// WithSecretStore replaces the default store which keeps secrets in memory
+func WithSecretStore(store SecretStore) PokerFaceOpt {
+	return func(pf *pokerface) {
+		pf.store = store
+	}
 }
 
-func NewPokerFace() PokerFace {
-	return &pokerface{}
+// WithAuditor audits games after every event
+func WithAuditor(h AuditHandler) PokerFaceOpt {
+	return func(pf *pokerface) {
+		pf.auditor = h
+	}
+}
+
+func NewPokerFace(opts ...PokerFaceOpt) PokerFace {
+
+	pf := &pokerface{
+		store: NewMemorySecretStore(),
+	}
+
+	for _, opt := range opts {
+		opt(pf)
+	}
+
+	return pf
 }
 
 func (pf *pokerface) NewGame(opts *GameOptions) Game {
 	g := NewGame(opts)
+	g.store = pf.store
+	g.auditor = pf.auditor
 	s := g.GetState()
 	s.GameID = uuid.New().String()
 	s.CreatedAt = time.Now().Unix()
@@ -29,6 +60,59 @@ func (pf *pokerface) NewGame(opts *GameOptions) Game {
 	return g
 }
 
+// NewGameFromState returns nil if state cannot be loaded.
+//
+// Deprecated: use LoadGame which returns the reason.
 func (pf *pokerface) NewGameFromState(gs *GameState) Game {
-	return NewGameFromState(gs)
+
+	g, err := pf.loadGame(gs)
+	if err != nil {
+		return nil
+	}
+
+	return g
+}
+
+func (pf *pokerface) LoadGame(gs *GameState) (Game, error) {
+
+	g, err := pf.loadGame(gs)
+	if err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+func (pf *pokerface) loadGame(gs *GameState) (*game, error) {
+
+	// Legacy state has deck already
+	legacy := isLegacyState(gs)
+
+	g := &game{
+		players: make(map[int]Player),
+		store:   pf.store,
+		auditor: pf.auditor,
+	}
+
+	err := g.LoadState(gs)
+	if err != nil {
+		return g, err
+	}
+
+	if legacy {
+		return g, nil
+	}
+
+	s, err := pf.store.LoadSecrets(gs.GameID)
+	if err != nil {
+
+		// Closed game has no secrets
+		if errors.Is(err, ErrSecretsNotFound) && isClosedEvent(gs.Status.CurrentEvent) {
+			return g, nil
+		}
+
+		return g, err
+	}
+
+	return g, g.LoadSecrets(s)
 }
@@ -10,11 +10,21 @@ func (g *game) updatePots() error {
 
 	ll := pot.NewLevelList()
 
+	var dead int64
 	for _, p := range g.gs.Players {
 		ll.AddContributor(p.Pot+p.Wager, p.Idx, p.Fold)
+		dead += p.DeadBlind
 	}
 
-	g.gs.Status.Pots = ll.GetPots()
+	pots := ll.GetPots()
+
+	// Dead blinds belong to whoever wins the main pot
+	if dead > 0 && len(pots) > 0 && len(pots[0].Levels) > 0 {
+		pots[0].Total += dead
+		pots[0].Levels[0].Total += dead
+	}
+
+	g.gs.Status.Pots = pots
 
 	return nil
 }
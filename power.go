@@ -3,16 +3,51 @@ package pokerface
 import (
 	"sort"
 
+	"github.com/weedbox/pokerface/card"
 	"github.com/weedbox/pokerface/combination"
 )
 
+// Evaluator returns evaluator of game rule, it returns nil if evaluator is unknown.
+// Jokers and cards of wild ranks are substituted before evaluation.
+func (g *game) Evaluator() combination.Evaluator {
+
+	e, err := combination.NewEvaluator(g.gs.Meta.Evaluator, g.gs.Meta.CombinationPowers)
+	if err != nil {
+		return nil
+	}
+
+	return combination.NewWildEvaluator(e, g.gs.Meta.WildRanks)
+}
+
+// Locale returns locale for descriptions, it returns nil if locale is unknown.
+func (g *game) Locale() *combination.Locale {
+
+	l, err := combination.GetLocale(g.gs.Meta.Locale)
+	if err != nil {
+		return nil
+	}
+
+	return l
+}
+
 func (g *game) CalculatePlayerPower(p *PlayerState) *combination.PowerState {
 
-	// calculate power with player state
-	powers := g.GetAllPowersByPlayer(p)
+	e := g.Evaluator()
 
-	// The first combination is the best result
-	return powers[0]
+	// Find out the best combination
+	var best []card.Card
+	bestScore := uint64(0)
+	for _, c := range g.GetAllPossibileCombinations(p, g.gs.Meta.RequiredHoleCardsCount) {
+
+		score := e.Evaluate(c)
+		if best == nil || score > bestScore {
+			best = c
+			bestScore = score
+		}
+	}
+
+	// Calculate details of the best combination
+	return e.CalculatePower(best)
 }
 
 func (g *game) UpdateCombinationOfAllPlayers() error {
@@ -27,12 +62,18 @@ func (g *game) UpdateCombinationOfAllPlayers() error {
 		p.Combination.Type = combination.CombinationSymbol[ps.Combination]
 
 		// Override old cards
-		p.Combination.Cards = make([]string, 0)
+		p.Combination.Cards = make([]card.Card, 0)
 		for _, c := range ps.Cards {
-			p.Combination.Cards = append(p.Combination.Cards, c.ToString())
+			cc, err := c.ToCard()
+			if err != nil {
+				return err
+			}
+
+			p.Combination.Cards = append(p.Combination.Cards, cc)
 		}
 
 		p.Combination.Power = int(ps.Score)
+		p.Combination.Description = combination.Describe(ps).Localize(g.Locale())
 	}
 
 	return nil
@@ -57,10 +98,10 @@ func (g *game) GetAllPowersByPlayer(p *PlayerState) []*combination.PowerState {
 	return powers
 }
 
-func (g *game) CalculateCombinationPower(cards []string) *combination.PowerState {
-	return combination.CalculatePower(g.gs.Meta.CombinationPowers, cards)
+func (g *game) CalculateCombinationPower(cards []card.Card) *combination.PowerState {
+	return g.Evaluator().CalculatePower(cards)
 }
 
-func (g *game) GetAllPossibileCombinations(p *PlayerState, holeCardsCount int) [][]string {
+func (g *game) GetAllPossibileCombinations(p *PlayerState, holeCardsCount int) [][]card.Card {
 	return combination.GetAllPossibleCombinations(g.gs.Status.Board, p.HoleCards, holeCardsCount)
 }
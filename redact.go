@@ -0,0 +1,159 @@
+package pokerface
+
+import (
+	"github.com/weedbox/pokerface/card"
+)
+
+// ViewerObserver is the viewer who is not a player of the game
+const ViewerObserver = -1
+
+// RedactionPolicy decides which private information is visible to viewers.
+// The zero value hides everything until the game is closed, then hands of
+// players who didn't fold are revealed.
+type RedactionPolicy struct {
+	// Staff sees everything, including deck and burned cards
+	Staff bool `json:"staff"`
+
+	// ExposeAllIn reveals hands once no more betting is possible because
+	// players are all-in
+	ExposeAllIn bool `json:"expose_all_in"`
+
+	// MuckLosers hides hands which win nothing at showdown
+	MuckLosers bool `json:"muck_losers"`
+
+	// UpCards are indexes of hole cards which are dealt face up (e.g. stud)
+	UpCards []int `json:"up_cards,omitempty"`
+}
+
+func NewRedactionPolicy() *RedactionPolicy {
+	return &RedactionPolicy{}
+}
+
+func NewStaffRedactionPolicy() *RedactionPolicy {
+	return &RedactionPolicy{
+		Staff: true,
+	}
+}
+
+// Redact returns a copy of game state for viewer which is index of player or
+// ViewerObserver. The original state is not modified.
+func (gs *GameState) Redact(viewer int, policy *RedactionPolicy) *GameState {
+
+	state := gs.Clone()
+	if state == nil {
+		return nil
+	}
+
+	state.redact(viewer, policy)
+
+	return state
+}
+
+func (gs *GameState) redact(viewer int, policy *RedactionPolicy) {
+
+	if policy == nil {
+		policy = NewRedactionPolicy()
+	}
+
+	if policy.Staff {
+		return
+	}
+
+	gs.Meta.Deck = []card.Card{}
+	gs.Status.Burned = []card.Card{}
+
+	if gs.Status.CardsRequest != nil {
+		gs.Status.CardsRequest.Cards = nil
+	}
+
+	closed := gs.Status.CurrentEvent == "GameClosed"
+	allin := policy.ExposeAllIn && gs.isAllinShowdown()
+
+	for _, p := range gs.Players {
+
+		if p.Idx == viewer {
+			continue
+		}
+
+		if !p.Fold {
+
+			// Showdown
+			if closed && (!policy.MuckLosers || gs.isWinner(p.Idx)) {
+				continue
+			}
+
+			if allin {
+				continue
+			}
+		}
+
+		// Only face up and shown cards are left
+		visible := make(map[int]bool)
+		for _, idx := range policy.UpCards {
+			visible[idx] = true
+		}
+
+		for _, idx := range p.ShownCards {
+			visible[idx] = true
+		}
+
+		cards := []card.Card{}
+		for i, c := range p.HoleCards {
+			if visible[i] {
+				cards = append(cards, c)
+			}
+		}
+
+		if len(cards) < len(p.HoleCards) {
+			p.Combination = nil
+		}
+
+		p.HoleCards = cards
+	}
+}
+
+// isAllinShowdown returns true if there are players in the game and no one
+// is able to bet anymore
+func (gs *GameState) isAllinShowdown() bool {
+
+	alive := 0
+	movable := 0
+	for _, p := range gs.Players {
+
+		if p.Fold {
+			continue
+		}
+
+		alive++
+
+		if p.StackSize == 0 {
+			continue
+		}
+
+		movable++
+
+		// Player has to call yet
+		if p.Wager < gs.Status.CurrentWager {
+			return false
+		}
+	}
+
+	return alive > 1 && movable <= 1
+}
+
+func (gs *GameState) isWinner(idx int) bool {
+
+	if gs.Result == nil {
+		return false
+	}
+
+	for _, pr := range gs.Result.Pots {
+		for _, w := range pr.Winners {
+			if w.Idx == idx {
+				return true
+			}
+		}
+	}
+
+	return false
+}
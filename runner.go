@@ -0,0 +1,378 @@
+package pokerface
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/weedbox/pokerface/card"
+)
+
+var (
+	ErrRunnerClosed     = errors.New("runner: closed")
+	ErrNotCurrentPlayer = errors.New("runner: not the current player")
+	ErrUnknownPlayer    = errors.New("runner: unknown player")
+	ErrCommandPanicked  = errors.New("runner: command panicked")
+)
+
+// Command is an operation which runner executes on its own goroutine
+type Command interface {
+	Execute(g Game) error
+}
+
+// Operations
+type CmdStart struct{}
+type CmdReadyForAll struct{}
+type CmdPayAnte struct{}
+type CmdPayBlinds struct{}
+type CmdNext struct{}
+
+type CmdPushCards struct {
+	Cards []card.Card
+}
+
+type CmdCorrectCard struct {
+	Misread card.Card
+	Actual  card.Card
+}
+
+type CmdMisdeal struct {
+	Reason string
+}
+
+// CmdGetState does nothing but returns the current state
+type CmdGetState struct{}
+
+// CmdFunc runs function with game, it is for operations which have no command
+type CmdFunc func(g Game) error
+
+// Actions are made by the current player only
+type CmdPass struct{ Player int }
+type CmdFold struct{ Player int }
+type CmdCheck struct{ Player int }
+type CmdCall struct{ Player int }
+type CmdAllin struct{ Player int }
+
+type CmdPay struct {
+	Player int
+	Chips  int64
+}
+
+type CmdBet struct {
+	Player int
+	Chips  int64
+}
+
+type CmdRaise struct {
+	Player    int
+	ChipLevel int64
+}
+
+type CmdShow struct {
+	Player int
+	Cards  []int
+}
+
+func (c CmdStart) Execute(g Game) error       { return g.Start() }
+func (c CmdReadyForAll) Execute(g Game) error { return g.ReadyForAll() }
+func (c CmdPayAnte) Execute(g Game) error     { return g.PayAnte() }
+func (c CmdPayBlinds) Execute(g Game) error   { return g.PayBlinds() }
+func (c CmdNext) Execute(g Game) error        { return g.Next() }
+func (c CmdPushCards) Execute(g Game) error   { return g.PushCards(c.Cards...) }
+func (c CmdCorrectCard) Execute(g Game) error { return g.CorrectCard(c.Misread, c.Actual) }
+func (c CmdMisdeal) Execute(g Game) error     { return g.Misdeal(c.Reason) }
+func (c CmdGetState) Execute(g Game) error    { return nil }
+func (c CmdFunc) Execute(g Game) error        { return c(g) }
+
+func (c CmdPass) Execute(g Game) error {
+	return actAs(g, c.Player, g.Pass)
+}
+
+func (c CmdFold) Execute(g Game) error {
+	return actAs(g, c.Player, g.Fold)
+}
+
+func (c CmdCheck) Execute(g Game) error {
+	return actAs(g, c.Player, g.Check)
+}
+
+func (c CmdCall) Execute(g Game) error {
+	return actAs(g, c.Player, g.Call)
+}
+
+func (c CmdAllin) Execute(g Game) error {
+	return actAs(g, c.Player, g.Allin)
+}
+
+func (c CmdPay) Execute(g Game) error {
+	return actAs(g, c.Player, func() error {
+		return g.Pay(c.Chips)
+	})
+}
+
+func (c CmdBet) Execute(g Game) error {
+	return actAs(g, c.Player, func() error {
+		return g.Bet(c.Chips)
+	})
+}
+
+func (c CmdRaise) Execute(g Game) error {
+	return actAs(g, c.Player, func() error {
+		return g.Raise(c.ChipLevel)
+	})
+}
+
+func (c CmdShow) Execute(g Game) error {
+
+	p := g.Player(c.Player)
+	if p == nil {
+		return ErrUnknownPlayer
+	}
+
+	return p.Show(c.Cards...)
+}
+
+// actAs makes sure action which was decided by player is not out of turn
+func actAs(g Game, idx int, fn func() error) error {
+
+	if g.Player(idx) == nil {
+		return ErrUnknownPlayer
+	}
+
+	p := g.GetCurrentPlayer()
+	if p == nil || p.SeatIndex() != idx {
+		return ErrNotCurrentPlayer
+	}
+
+	return fn()
+}
+
+// Future is result of command which will be available after execution
+type Future struct {
+	done  chan struct{}
+	state *GameState
+	err   error
+}
+
+func newFuture() *Future {
+	return &Future{
+		done: make(chan struct{}),
+	}
+}
+
+func (f *Future) resolve(gs *GameState, err error) {
+	f.state = gs
+	f.err = err
+	close(f.done)
+}
+
+// Done is closed once command was executed
+func (f *Future) Done() <-chan struct{} {
+	return f.done
+}
+
+// Wait blocks until command was executed, then returns state after execution
+func (f *Future) Wait() (*GameState, error) {
+	<-f.done
+	return f.state, f.err
+}
+
+type request struct {
+	cmd    Command
+	future *Future
+}
+
+// Runner owns a game and executes commands one at a time on its goroutine,
+// so it can be used from many goroutines without locking. States which are
+// returned and published are copies.
+type Runner struct {
+	g        Game
+	requests chan *request
+	closed   chan struct{}
+	stopped  chan struct{}
+
+	// Submitting is not allowed once runner is closing
+	sending sync.RWMutex
+	closing bool
+
+	mu          sync.Mutex
+	subscribers map[int]chan *GameState
+	nextID      int
+}
+
+func NewRunner(g Game) *Runner {
+
+	r := &Runner{
+		g:           g,
+		requests:    make(chan *request, 64),
+		closed:      make(chan struct{}),
+		stopped:     make(chan struct{}),
+		subscribers: make(map[int]chan *GameState),
+	}
+
+	go r.run()
+
+	return r
+}
+
+func (r *Runner) run() {
+
+	defer close(r.stopped)
+
+	for {
+		select {
+		case <-r.closed:
+			r.drain()
+			return
+		case req := <-r.requests:
+			r.execute(req)
+		}
+	}
+}
+
+// drain rejects commands which were submitted before runner was closed
+func (r *Runner) drain() {
+	for {
+		select {
+		case req := <-r.requests:
+			req.future.resolve(nil, ErrRunnerClosed)
+		default:
+			return
+		}
+	}
+}
+
+func (r *Runner) execute(req *request) {
+
+	err := r.invoke(req.cmd)
+	gs := r.g.GetState().Clone()
+
+	req.future.resolve(gs, err)
+
+	if err != nil {
+		return
+	}
+
+	if _, ok := req.cmd.(CmdGetState); ok {
+		return
+	}
+
+	r.publish(gs.Clone())
+}
+
+func (r *Runner) invoke(cmd Command) (err error) {
+
+	// Game state might be broken, but runner keeps serving
+	defer func() {
+		if v := recover(); v != nil {
+			err = fmt.Errorf("%w: %v", ErrCommandPanicked, v)
+		}
+	}()
+
+	return cmd.Execute(r.g)
+}
+
+// publish sends state to subscribers, state is dropped for the subscriber
+// which doesn't keep up. Subscribers share the same copy.
+func (r *Runner) publish(gs *GameState) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, ch := range r.subscribers {
+		select {
+		case ch <- gs:
+		default:
+		}
+	}
+}
+
+// Submit queues command without waiting for execution. It blocks while queue
+// is full, so commands should not submit others and wait for them.
+func (r *Runner) Submit(cmd Command) *Future {
+
+	f := newFuture()
+
+	r.sending.RLock()
+	defer r.sending.RUnlock()
+
+	if r.closing {
+		f.resolve(nil, ErrRunnerClosed)
+		return f
+	}
+
+	r.requests <- &request{cmd: cmd, future: f}
+
+	return f
+}
+
+// Do executes command and waits for result
+func (r *Runner) Do(cmd Command) (*GameState, error) {
+	return r.Submit(cmd).Wait()
+}
+
+// GetState returns a copy of the current state
+func (r *Runner) GetState() (*GameState, error) {
+	return r.Do(CmdGetState{})
+}
+
+// Subscribe receives states after every successful command until cancel
+// function is called.
+func (r *Runner) Subscribe(buffer int) (<-chan *GameState, func()) {
+
+	ch := make(chan *GameState, buffer)
+
+	r.sending.RLock()
+	defer r.sending.RUnlock()
+
+	if r.closing {
+		close(ch)
+		return ch, func() {}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := r.nextID
+	r.nextID++
+	r.subscribers[id] = ch
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+
+			if _, ok := r.subscribers[id]; ok {
+				delete(r.subscribers, id)
+				close(ch)
+			}
+		})
+	}
+
+	return ch, cancel
+}
+
+// Close stops runner after the executing command, commands in queue are
+// rejected and channels of subscribers are closed.
+func (r *Runner) Close() error {
+
+	r.sending.Lock()
+	if !r.closing {
+		r.closing = true
+		close(r.closed)
+	}
+	r.sending.Unlock()
+
+	<-r.stopped
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, ch := range r.subscribers {
+		delete(r.subscribers, id)
+		close(ch)
+	}
+
+	return nil
+}
@@ -0,0 +1,405 @@
+package scenario
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/weedbox/pokerface"
+	"github.com/weedbox/pokerface/card"
+	"github.com/weedbox/pokerface/table"
+)
+
+// Failure is a step which did not go as expected
+type Failure struct {
+	Line     int
+	Step     string
+	Expected string
+	Actual   string
+}
+
+func (f *Failure) String() string {
+	return fmt.Sprintf("line %d: %s\n  - expected: %s\n  + actual:   %s", f.Line, f.Step, f.Expected, f.Actual)
+}
+
+// Report is result of running a scenario
+type Report struct {
+	Scenario *Scenario
+	Failures []*Failure
+	State    *pokerface.GameState
+}
+
+func (r *Report) Passed() bool {
+	return len(r.Failures) == 0
+}
+
+func (r *Report) String() string {
+
+	if r.Passed() {
+		return fmt.Sprintf("%s: passed", r.Scenario.Name)
+	}
+
+	lines := make([]string, 0, len(r.Failures)+1)
+	lines = append(lines, fmt.Sprintf("%s (%s): %d failure(s)", r.Scenario.Name, r.Scenario.File, len(r.Failures)))
+	for _, f := range r.Failures {
+		lines = append(lines, f.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// engine drives a game for runner, actions are made by the current player
+type engine interface {
+	start(opts *pokerface.GameOptions) error
+	state() *pokerface.GameState
+	do(action string, chips int64) error
+}
+
+// RunGame runs scenario against pokerface.Game directly
+func RunGame(s *Scenario) *Report {
+	return run(s, &gameEngine{
+		pf: pokerface.NewPokerFace(),
+	})
+}
+
+// RunBackend runs scenario against table backend, game is rebuilt from state
+// for every step.
+func RunBackend(s *Scenario, b table.Backend) *Report {
+	return run(s, &backendEngine{
+		b: b,
+	})
+}
+
+func run(s *Scenario, e engine) *Report {
+
+	r := &Report{
+		Scenario: s,
+		Failures: make([]*Failure, 0),
+	}
+
+	if err := e.start(s.Options); err != nil {
+		r.Failures = append(r.Failures, &Failure{
+			Step:     "start",
+			Expected: "game started",
+			Actual:   err.Error(),
+		})
+		return r
+	}
+
+	var lastErr error
+	for i, step := range s.Steps {
+
+		if step.IsCheckpoint() {
+
+			if f := check(step, e.state(), lastErr); f != nil {
+				r.Failures = append(r.Failures, f)
+			}
+
+			lastErr = nil
+			continue
+		}
+
+		lastErr = act(step, e)
+		if lastErr == nil {
+
+			// Invariants are checked after every action
+			for _, v := range pokerface.Audit(e.state()) {
+				r.Failures = append(r.Failures, &Failure{
+					Line:     step.Line,
+					Step:     step.Text,
+					Expected: "no violation",
+					Actual:   v.String(),
+				})
+			}
+
+			continue
+		}
+
+		// Error is expected by the next step
+		if i+1 < len(s.Steps) && s.Steps[i+1].IsCheckpoint() && s.Steps[i+1].Args[0] == "error" {
+			continue
+		}
+
+		r.Failures = append(r.Failures, &Failure{
+			Line:     step.Line,
+			Step:     step.Text,
+			Expected: "no error",
+			Actual:   lastErr.Error(),
+		})
+
+		break
+	}
+
+	r.State = e.state()
+
+	return r
+}
+
+func act(step *Step, e engine) error {
+
+	switch step.Command {
+	case "ready":
+		return e.do("ready", 0)
+	case "pay_ante":
+		return e.do("pay_ante", 0)
+	case "pay_blinds":
+		return e.do("pay_blinds", 0)
+	case "next":
+		return e.do("next", 0)
+	}
+
+	// Player action
+	idx, _ := parsePlayer(step.Command)
+
+	gs := e.state()
+	if gs.Status.CurrentPlayer != idx {
+		return fmt.Errorf("p%d is not the current player (current: p%d)", idx, gs.Status.CurrentPlayer)
+	}
+
+	var chips int64
+	if len(step.Args) > 1 {
+		chips, _ = strconv.ParseInt(step.Args[1], 10, 64)
+	}
+
+	return e.do(step.Args[0], chips)
+}
+
+func check(step *Step, gs *pokerface.GameState, lastErr error) *Failure {
+
+	args := step.Args[1:]
+	expected := strings.Join(args, " ")
+	actual := ""
+
+	fail := func() *Failure {
+		return &Failure{
+			Line:     step.Line,
+			Step:     step.Text,
+			Expected: expected,
+			Actual:   actual,
+		}
+	}
+
+	player := func(s string) *pokerface.PlayerState {
+		idx, err := parsePlayer(s)
+		if err != nil {
+			return nil
+		}
+
+		return gs.GetPlayer(idx)
+	}
+
+	switch step.Args[0] {
+	case "error":
+
+		if lastErr == nil {
+			actual = "no error"
+			if len(args) == 0 {
+				expected = "error"
+			}
+			return fail()
+		}
+
+		actual = lastErr.Error()
+		if len(args) > 0 && !strings.Contains(actual, expected) {
+			return fail()
+		}
+
+		return nil
+
+	case "event":
+		actual = gs.Status.CurrentEvent
+	case "round":
+		actual = gs.Status.Round
+	case "current":
+		actual = fmt.Sprintf("p%d", gs.Status.CurrentPlayer)
+	case "board":
+		actual = strings.Join(card.Strings(gs.Status.Board), " ")
+	case "pots":
+
+		totals := make([]string, len(gs.Status.Pots))
+		for i, p := range gs.Status.Pots {
+			totals[i] = strconv.FormatInt(p.Total, 10)
+		}
+
+		actual = strings.Join(totals, " ")
+
+	case "allowed":
+
+		p := player(args[0])
+		if p == nil {
+			actual = "unknown player " + args[0]
+			return fail()
+		}
+
+		expected = sortedActions(args[1:])
+		actual = sortedActions(p.AllowedActions)
+
+	case "stack", "wager", "result":
+
+		p := player(args[0])
+		if p == nil {
+			actual = "unknown player " + args[0]
+			return fail()
+		}
+
+		expected = args[1]
+
+		switch step.Args[0] {
+		case "stack":
+			actual = strconv.FormatInt(p.StackSize, 10)
+		case "wager":
+			actual = strconv.FormatInt(p.Wager, 10)
+		case "result":
+			actual = "no result"
+			if gs.Result != nil {
+				for _, pr := range gs.Result.Players {
+					if pr.Idx == p.Idx {
+						actual = strconv.FormatInt(pr.Changed, 10)
+					}
+				}
+			}
+
+			// Sign of positive number is optional
+			expected = strings.TrimPrefix(expected, "+")
+		}
+	}
+
+	if actual != expected {
+		return fail()
+	}
+
+	return nil
+}
+
+// sortedActions makes allowed actions comparable, "-" means no action
+func sortedActions(actions []string) string {
+
+	sorted := make([]string, 0, len(actions))
+	for _, a := range actions {
+		if a != "-" {
+			sorted = append(sorted, a)
+		}
+	}
+
+	if len(sorted) == 0 {
+		return "-"
+	}
+
+	sort.Strings(sorted)
+
+	return strings.Join(sorted, " ")
+}
+
+type gameEngine struct {
+	pf pokerface.PokerFace
+	g  pokerface.Game
+}
+
+func (ge *gameEngine) start(opts *pokerface.GameOptions) error {
+	ge.g = ge.pf.NewGame(opts)
+	return ge.g.Start()
+}
+
+func (ge *gameEngine) state() *pokerface.GameState {
+	return ge.g.GetState()
+}
+
+func (ge *gameEngine) do(action string, chips int64) error {
+
+	g := ge.g
+
+	switch action {
+	case "ready":
+		return g.ReadyForAll()
+	case "pay_ante":
+		return g.PayAnte()
+	case "pay_blinds":
+		return g.PayBlinds()
+	case "next":
+		return g.Next()
+	case "pass":
+		return g.Pass()
+	case "pay":
+		return g.Pay(chips)
+	case "fold":
+		return g.Fold()
+	case "check":
+		return g.Check()
+	case "call":
+		return g.Call()
+	case "allin":
+		return g.Allin()
+	case "bet":
+		return g.Bet(chips)
+	case "raise":
+		return g.Raise(chips)
+	}
+
+	return ErrUnknownDirective
+}
+
+type backendEngine struct {
+	b  table.Backend
+	gs *pokerface.GameState
+}
+
+func (be *backendEngine) start(opts *pokerface.GameOptions) error {
+
+	gs, err := be.b.CreateGame(opts)
+	if err != nil {
+		return err
+	}
+
+	be.gs = gs
+
+	return nil
+}
+
+func (be *backendEngine) state() *pokerface.GameState {
+	return be.gs
+}
+
+func (be *backendEngine) do(action string, chips int64) error {
+
+	b := be.b
+	gs := be.gs
+
+	var err error
+	switch action {
+	case "ready":
+		gs, err = b.ReadyForAll(gs)
+	case "pay_ante":
+		gs, err = b.PayAnte(gs)
+	case "pay_blinds":
+		gs, err = b.PayBlinds(gs)
+	case "next":
+		gs, err = b.Next(gs)
+	case "pass":
+		gs, err = b.Pass(gs)
+	case "pay":
+		gs, err = b.Pay(gs, chips)
+	case "fold":
+		gs, err = b.Fold(gs)
+	case "check":
+		gs, err = b.Check(gs)
+	case "call":
+		gs, err = b.Call(gs)
+	case "allin":
+		gs, err = b.Allin(gs)
+	case "bet":
+		gs, err = b.Bet(gs, chips)
+	case "raise":
+		gs, err = b.Raise(gs, chips)
+	default:
+		return ErrUnknownDirective
+	}
+
+	if err != nil {
+		return err
+	}
+
+	be.gs = gs
+
+	return nil
+}
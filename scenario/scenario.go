@@ -0,0 +1,508 @@
+package scenario
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/weedbox/pokerface"
+	"github.com/weedbox/pokerface/card"
+)
+
+var (
+	ErrUnknownDirective = errors.New("scenario: unknown directive")
+	ErrInvalidArguments = errors.New("scenario: invalid arguments")
+	ErrUnknownGame      = errors.New("scenario: unknown game")
+	ErrUnknownPlayer    = errors.New("scenario: unknown player")
+	ErrNoSeat           = errors.New("scenario: no seat")
+	ErrMixedDeck        = errors.New("scenario: deck cannot be used with hole and board")
+	ErrDuplicateCard    = errors.New("scenario: duplicate card")
+)
+
+// Scenario is a hand which is described in text, for example:
+//
+//	name Heads-up limp and check down
+//	blinds 5 10
+//	seat 1000 dealer sb
+//	seat 1000 bb
+//	hole p0 SA SK
+//	board HA H7 D2 C9 S3
+//
+//	ready
+//	pay_blinds
+//	ready
+//	expect allowed p0 fold call raise allin
+//	p0 call
+//	p1 check
+//	next
+//	expect pots 20
+//
+// Lines before the first step set up the game, the rest are steps which
+// are actions and checkpoints. Anything after "#" is ignored.
+type Scenario struct {
+	Name    string
+	File    string
+	Options *pokerface.GameOptions
+	Steps   []*Step
+}
+
+// Step is an action or a checkpoint (starts with "expect")
+type Step struct {
+	Line    int
+	Text    string
+	Command string
+	Args    []string
+}
+
+func (s *Step) IsCheckpoint() bool {
+	return s.Command == "expect"
+}
+
+type setup struct {
+	game  string
+	seats int
+	deck  []card.Card
+	holes map[int][]card.Card
+	board []card.Card
+}
+
+// Load parses all files which match the pattern
+func Load(pattern string) ([]*Scenario, error) {
+
+	files, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	scenarios := make([]*Scenario, 0, len(files))
+	for _, f := range files {
+
+		s, err := ParseFile(f)
+		if err != nil {
+			return nil, err
+		}
+
+		scenarios = append(scenarios, s)
+	}
+
+	return scenarios, nil
+}
+
+func ParseFile(path string) (*Scenario, error) {
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Parse(path, f)
+}
+
+func Parse(file string, r io.Reader) (*Scenario, error) {
+
+	s := &Scenario{
+		Name:    filepath.Base(file),
+		File:    file,
+		Options: pokerface.NewStardardGameOptions(),
+		Steps:   make([]*Step, 0),
+	}
+
+	st := &setup{
+		holes: make(map[int][]card.Card),
+	}
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+
+		line++
+
+		text := scanner.Text()
+		if i := strings.Index(text, "#"); i >= 0 {
+			text = text[:i]
+		}
+
+		text = strings.TrimSpace(text)
+		if len(text) == 0 {
+			continue
+		}
+
+		fields := strings.Fields(text)
+		step := &Step{
+			Line:    line,
+			Text:    text,
+			Command: fields[0],
+			Args:    fields[1:],
+		}
+
+		// Setup is allowed before steps only
+		if len(s.Steps) == 0 {
+
+			ok, err := s.parseSetup(st, step)
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: %w", file, line, err)
+			}
+
+			if ok {
+				continue
+			}
+		}
+
+		if err := validateStep(step); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", file, line, err)
+		}
+
+		s.Steps = append(s.Steps, step)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if st.seats == 0 {
+		return nil, fmt.Errorf("%s: %w", file, ErrNoSeat)
+	}
+
+	deck, err := st.buildDeck(s.Options)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+
+	s.Options.Deck = deck
+	s.Options.StackedDeck = true
+
+	return s, nil
+}
+
+func (s *Scenario) parseSetup(st *setup, step *Step) (bool, error) {
+
+	args := step.Args
+	opts := s.Options
+
+	switch step.Command {
+	case "name":
+		s.Name = strings.TrimSpace(strings.TrimPrefix(step.Text, "name"))
+	case "game":
+
+		if len(args) != 1 || st.seats > 0 {
+			return true, ErrInvalidArguments
+		}
+
+		var o *pokerface.GameOptions
+		switch args[0] {
+		case "standard":
+			o = pokerface.NewStardardGameOptions()
+		case "short_deck":
+			o = pokerface.NewShortDeckGameOptions()
+		case "lowball_a5":
+			o = pokerface.NewLowballA5GameOptions()
+		case "lowball_27":
+			o = pokerface.NewLowball27GameOptions()
+		default:
+			return true, ErrUnknownGame
+		}
+
+		// Settings which were given already are kept
+		o.Ante = opts.Ante
+		o.Blind = opts.Blind
+		o.Limit = opts.Limit
+		s.Options = o
+		st.game = args[0]
+
+	case "limit":
+
+		if len(args) != 1 {
+			return true, ErrInvalidArguments
+		}
+
+		opts.Limit = args[0]
+
+	case "ante":
+
+		values, err := parseChips(args, 1, 1)
+		if err != nil {
+			return true, err
+		}
+
+		opts.Ante = values[0]
+
+	case "blinds":
+
+		values, err := parseChips(args, 2, 3)
+		if err != nil {
+			return true, err
+		}
+
+		opts.Blind.SB = values[0]
+		opts.Blind.BB = values[1]
+		if len(values) == 3 {
+			opts.Blind.Dealer = values[2]
+		}
+
+	case "seat":
+
+		if len(args) == 0 {
+			return true, ErrInvalidArguments
+		}
+
+		values, err := parseChips(args[:1], 1, 1)
+		if err != nil {
+			return true, err
+		}
+
+		opts.Players = append(opts.Players, &pokerface.PlayerSetting{
+			Bankroll:  values[0],
+			Positions: append(make([]string, 0), args[1:]...),
+		})
+
+		st.seats++
+
+	case "deck":
+
+		cards, err := card.ParseCards(args)
+		if err != nil {
+			return true, err
+		}
+
+		st.deck = append(st.deck, cards...)
+
+	case "hole":
+
+		if len(args) < 2 {
+			return true, ErrInvalidArguments
+		}
+
+		idx, err := parsePlayer(args[0])
+		if err != nil {
+			return true, err
+		}
+
+		cards, err := card.ParseCards(args[1:])
+		if err != nil {
+			return true, err
+		}
+
+		st.holes[idx] = cards
+
+	case "board":
+
+		cards, err := card.ParseCards(args)
+		if err != nil || len(cards) > 5 {
+			return true, ErrInvalidArguments
+		}
+
+		st.board = cards
+
+	default:
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// buildDeck arranges cards in dealing order of engine: hole cards of every
+// player, then burn and flop, burn and turn, burn and river. Cards which are
+// not specified are taken from the rest of deck.
+func (st *setup) buildDeck(opts *pokerface.GameOptions) ([]card.Card, error) {
+
+	full := pokerface.NewStandardDeckCards()
+	if st.game == "short_deck" {
+		full = pokerface.NewShortDeckCards()
+	}
+
+	if len(st.deck) > 0 && (len(st.holes) > 0 || len(st.board) > 0) {
+		return nil, ErrMixedDeck
+	}
+
+	used := card.NewCardSet()
+	use := func(cards []card.Card) error {
+		for _, c := range cards {
+
+			if used.Contains(c) {
+				return fmt.Errorf("%w: %s", ErrDuplicateCard, c)
+			}
+
+			used = used.Add(c)
+		}
+
+		return nil
+	}
+
+	if len(st.deck) > 0 {
+
+		if err := use(st.deck); err != nil {
+			return nil, err
+		}
+
+		return append(st.deck, remaining(full, used)...), nil
+	}
+
+	for idx, cards := range st.holes {
+
+		if idx >= st.seats {
+			return nil, fmt.Errorf("%w: p%d", ErrUnknownPlayer, idx)
+		}
+
+		if len(cards) > opts.HoleCardsCount {
+			return nil, fmt.Errorf("%w: too many hole cards of p%d", ErrInvalidArguments, idx)
+		}
+
+		if err := use(cards); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := use(st.board); err != nil {
+		return nil, err
+	}
+
+	rest := remaining(full, used)
+	take := func(cards []card.Card, count int) []card.Card {
+
+		taken := append(make([]card.Card, 0, count), cards...)
+		for len(taken) < count && len(rest) > 0 {
+			taken = append(taken, rest[0])
+			rest = rest[1:]
+		}
+
+		return taken
+	}
+
+	deck := make([]card.Card, 0, len(full))
+	for i := 0; i < st.seats; i++ {
+		deck = append(deck, take(st.holes[i], opts.HoleCardsCount)...)
+	}
+
+	board := st.board
+	for _, count := range []int{3, 1, 1} {
+
+		cards := board
+		if len(cards) > count {
+			cards = cards[:count]
+		}
+		board = board[len(cards):]
+
+		// Burned card
+		deck = append(deck, take(nil, 1)...)
+		deck = append(deck, take(cards, count)...)
+	}
+
+	return append(deck, rest...), nil
+}
+
+func remaining(full []card.Card, used card.CardSet) []card.Card {
+
+	cards := make([]card.Card, 0, len(full))
+	for _, c := range full {
+		if !used.Contains(c) {
+			cards = append(cards, c)
+		}
+	}
+
+	return cards
+}
+
+func validateStep(step *Step) error {
+
+	args := step.Args
+
+	switch step.Command {
+	case "ready", "pay_ante", "pay_blinds", "next":
+
+		if len(args) != 0 {
+			return ErrInvalidArguments
+		}
+
+		return nil
+
+	case "expect":
+
+		if len(args) == 0 {
+			return ErrInvalidArguments
+		}
+
+		switch args[0] {
+		case "event", "round", "current":
+			if len(args) != 2 {
+				return ErrInvalidArguments
+			}
+		case "stack", "wager", "result":
+			if len(args) != 3 {
+				return ErrInvalidArguments
+			}
+		case "allowed":
+			if len(args) < 3 {
+				return ErrInvalidArguments
+			}
+		case "board", "pots", "error":
+		default:
+			return ErrUnknownDirective
+		}
+
+		return nil
+	}
+
+	// Player action
+	if _, err := parsePlayer(step.Command); err != nil {
+		return ErrUnknownDirective
+	}
+
+	if len(args) == 0 {
+		return ErrInvalidArguments
+	}
+
+	switch args[0] {
+	case "pass", "fold", "check", "call", "allin":
+		if len(args) != 1 {
+			return ErrInvalidArguments
+		}
+	case "bet", "raise", "pay":
+		if _, err := parseChips(args[1:], 1, 1); err != nil {
+			return err
+		}
+	default:
+		return ErrUnknownDirective
+	}
+
+	return nil
+}
+
+func parsePlayer(s string) (int, error) {
+
+	if !strings.HasPrefix(s, "p") {
+		return -1, ErrUnknownPlayer
+	}
+
+	idx, err := strconv.Atoi(s[1:])
+	if err != nil || idx < 0 {
+		return -1, ErrUnknownPlayer
+	}
+
+	return idx, nil
+}
+
+func parseChips(args []string, min int, max int) ([]int64, error) {
+
+	if len(args) < min || len(args) > max {
+		return nil, ErrInvalidArguments
+	}
+
+	values := make([]int64, len(args))
+	for i, a := range args {
+
+		v, err := strconv.ParseInt(a, 10, 64)
+		if err != nil {
+			return nil, ErrInvalidArguments
+		}
+
+		values[i] = v
+	}
+
+	return values, nil
+}
@@ -0,0 +1,125 @@
+package scenario
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokerface/card"
+	"github.com/weedbox/pokerface/table"
+)
+
+const headsUp = `
+name Heads-up
+blinds 5 10
+seat 1000 dealer sb  # button
+seat 800 bb
+hole p1 SA SK
+board HA H9 D4 C3
+
+ready
+pay_blinds
+ready
+expect current p0
+p0 fold
+next
+expect event GameClosed
+expect result p0 -5
+expect result p1 +5
+`
+
+func TestParse(t *testing.T) {
+
+	s, err := Parse("heads_up.scenario", strings.NewReader(headsUp))
+	assert.Nil(t, err)
+
+	assert.Equal(t, "Heads-up", s.Name)
+	assert.Equal(t, int64(5), s.Options.Blind.SB)
+	assert.Equal(t, int64(10), s.Options.Blind.BB)
+	assert.Equal(t, 2, len(s.Options.Players))
+	assert.Equal(t, int64(800), s.Options.Players[1].Bankroll)
+	assert.Equal(t, []string{"dealer", "sb"}, s.Options.Players[0].Positions)
+	assert.True(t, s.Options.StackedDeck)
+
+	// Hole cards of every player, burn, flop, burn, turn, burn, river
+	deck := s.Options.Deck
+	assert.Equal(t, 52, len(deck))
+	assert.Equal(t, 52, card.NewCardSet(deck...).Count())
+	assert.Equal(t, card.MustParseCards("SA", "SK"), deck[2:4])
+	assert.Equal(t, card.MustParseCards("HA", "H9", "D4"), deck[5:8])
+	assert.Equal(t, card.MustParse("C3"), deck[9])
+
+	assert.Equal(t, 9, len(s.Steps))
+	assert.Equal(t, 12, s.Steps[3].Line)
+	assert.True(t, s.Steps[3].IsCheckpoint())
+	assert.Equal(t, "p0", s.Steps[4].Command)
+}
+
+func TestParse_Errors(t *testing.T) {
+
+	cases := map[string]error{
+		"blinds 5\nseat 100":                   ErrInvalidArguments,
+		"seat 100\nready\nshuffle":             ErrUnknownDirective,
+		"seat 100\np0 raise":                   ErrInvalidArguments,
+		"seat 100\nexpect nothing":             ErrUnknownDirective,
+		"seat 100\ngame stud":                  ErrInvalidArguments,
+		"game stud":                            ErrUnknownGame,
+		"ready":                                ErrNoSeat,
+		"seat 100\nhole p1 SA SK":              ErrUnknownPlayer,
+		"seat 100\nhole p0 SA\nboard SA":       ErrDuplicateCard,
+		"seat 100\ndeck SA\nboard SK":          ErrMixedDeck,
+		"seat 100\nseat 100\nhole p0 SA SK C2": ErrInvalidArguments,
+	}
+
+	for text, expected := range cases {
+		_, err := Parse("error.scenario", strings.NewReader(text))
+		assert.True(t, errors.Is(err, expected), "%s: %v", text, err)
+	}
+
+	_, err := Parse("error.scenario", strings.NewReader("seat 100\n\nready\nshuffle"))
+	assert.Equal(t, "error.scenario:4: scenario: unknown directive", err.Error())
+}
+
+func TestRun(t *testing.T) {
+
+	s, err := Parse("heads_up.scenario", strings.NewReader(headsUp))
+	assert.Nil(t, err)
+
+	r := RunGame(s)
+	assert.True(t, r.Passed(), r.String())
+	assert.Equal(t, "GameClosed", r.State.Status.CurrentEvent)
+
+	r = RunBackend(s, table.NewNativeBackend())
+	assert.True(t, r.Passed(), r.String())
+}
+
+func TestRun_Failures(t *testing.T) {
+
+	text := strings.Replace(headsUp, "expect result p1 +5", "expect result p1 +10", 1)
+	text = strings.Replace(text, "expect current p0", "expect allowed p0 fold call", 1)
+
+	s, err := Parse("heads_up.scenario", strings.NewReader(text))
+	assert.Nil(t, err)
+
+	r := RunGame(s)
+	assert.False(t, r.Passed())
+	assert.Equal(t, 2, len(r.Failures))
+	assert.Equal(t, strings.Join([]string{
+		"Heads-up (heads_up.scenario): 2 failure(s)",
+		"line 12: expect allowed p0 fold call",
+		"  - expected: call fold",
+		"  + actual:   allin call fold raise",
+		"line 17: expect result p1 +10",
+		"  - expected: 10",
+		"  + actual:   5",
+	}, "\n"), r.String())
+
+	// Game stops at the first unexpected error
+	s, err = Parse("heads_up.scenario", strings.NewReader(strings.Replace(headsUp, "p0 fold", "p1 fold", 1)))
+	assert.Nil(t, err)
+
+	r = RunGame(s)
+	assert.Equal(t, 1, len(r.Failures))
+	assert.Equal(t, "p1 is not the current player (current: p0)", r.Failures[0].Actual)
+}
@@ -0,0 +1,175 @@
+package pokerface
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/weedbox/pokerface/combination"
+)
+
+var (
+	ErrUnsupportedSchemaVersion = errors.New("game: unsupported schema version")
+	ErrMissingMigration         = errors.New("game: missing migration")
+)
+
+// SchemaVersion is the version of GameState structure, it should be
+// increased with a migration whenever structure is changed.
+//
+// 0: documents were persisted before versioning
+// 1: evaluator and locale in meta
+// 2: deck and burned cards are kept in secrets
+const SchemaVersion = 2
+
+// Migration upgrades a state document from a version to the next one
+type Migration func(doc map[string]interface{}) error
+
+var migrations = struct {
+	sync.RWMutex
+	migrations map[int]Migration
+}{
+	migrations: map[int]Migration{
+		0: migrateV0,
+		1: migrateV1,
+	},
+}
+
+// RegisterMigration registers migration which upgrades document from the version
+func RegisterMigration(from int, m Migration) {
+
+	migrations.Lock()
+	defer migrations.Unlock()
+
+	migrations.migrations[from] = m
+}
+
+func getMigration(from int) (Migration, error) {
+
+	migrations.RLock()
+	defer migrations.RUnlock()
+
+	m, ok := migrations.migrations[from]
+	if !ok {
+		return nil, fmt.Errorf("%w: from version %d", ErrMissingMigration, from)
+	}
+
+	return m, nil
+}
+
+func checkSchemaVersion(version int) error {
+
+	if version > SchemaVersion || version < 0 {
+		return fmt.Errorf("%w: %d (supported up to %d)", ErrUnsupportedSchemaVersion, version, SchemaVersion)
+	}
+
+	return nil
+}
+
+// MigrateStateJSON upgrades state document to the current schema version
+func MigrateStateJSON(data []byte) ([]byte, error) {
+
+	var doc map[string]interface{}
+	err := json.Unmarshal(data, &doc)
+	if err != nil {
+		return nil, err
+	}
+
+	version := 0
+	if v, ok := doc["schema_version"].(float64); ok {
+		version = int(v)
+	}
+
+	err = checkSchemaVersion(version)
+	if err != nil {
+		return nil, err
+	}
+
+	if version == SchemaVersion {
+		return data, nil
+	}
+
+	for ; version < SchemaVersion; version++ {
+
+		m, err := getMigration(version)
+		if err != nil {
+			return nil, err
+		}
+
+		err = m(doc)
+		if err != nil {
+			return nil, err
+		}
+
+		doc["schema_version"] = version + 1
+	}
+
+	return json.Marshal(doc)
+}
+
+// UnmarshalStateJSON loads state document of any supported version
+func UnmarshalStateJSON(data []byte) (*GameState, error) {
+
+	data, err := MigrateStateJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var gs GameState
+	err = json.Unmarshal(data, &gs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gs, nil
+}
+
+// migrateState upgrades state in place
+func migrateState(gs *GameState) error {
+
+	err := checkSchemaVersion(gs.SchemaVersion)
+	if err != nil {
+		return err
+	}
+
+	if gs.SchemaVersion == SchemaVersion {
+		return nil
+	}
+
+	data, err := json.Marshal(gs)
+	if err != nil {
+		return err
+	}
+
+	migrated, err := UnmarshalStateJSON(data)
+	if err != nil {
+		return err
+	}
+
+	*gs = *migrated
+
+	return nil
+}
+
+func migrateV0(doc map[string]interface{}) error {
+
+	meta, ok := doc["meta"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	if _, ok := meta["evaluator"]; !ok {
+		meta["evaluator"] = combination.EvaluatorHigh
+	}
+
+	if _, ok := meta["locale"]; !ok {
+		meta["locale"] = combination.LocaleEnglish
+	}
+
+	return nil
+}
+
+// migrateV1 keeps deck of document, it is moved into secrets by LoadState
+func migrateV1(doc map[string]interface{}) error {
+	return nil
+}
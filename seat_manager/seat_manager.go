@@ -441,6 +441,45 @@ func (sm *SeatManager) ApplyStates(state *SeatManagerState) error {
 	return nil
 }
 
+// GetStates exports states of seats which can be applied with ApplyStates
+func (sm *SeatManager) GetStates() *SeatManagerState {
+
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	state := &SeatManagerState{
+		Max:    sm.max,
+		Seats:  make(map[int]*Seat, len(sm.seats)),
+		Dealer: -1,
+		SB:     -1,
+		BB:     -1,
+	}
+
+	for id, s := range sm.seats {
+		state.Seats[id] = &Seat{
+			ID:         s.ID,
+			IsActive:   s.IsActive,
+			IsReserved: s.IsReserved,
+			Player:     s.Player,
+		}
+	}
+
+	// Position states
+	if sm.dealer != nil {
+		state.Dealer = sm.dealer.ID
+	}
+
+	if sm.sb != nil {
+		state.SB = sm.sb.ID
+	}
+
+	if sm.bb != nil {
+		state.BB = sm.bb.ID
+	}
+
+	return state
+}
+
 func (sm *SeatManager) Dealer() *Seat {
 	return sm.dealer
 }
@@ -0,0 +1,178 @@
+package pokerface
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/weedbox/pokerface/card"
+	"github.com/weedbox/pokerface/codec"
+)
+
+var (
+	ErrSecretsNotFound = errors.New("game: secrets not found")
+)
+
+// Secrets are private information of game which is never in GameState, so
+// that state is safe to be cloned and broadcasted. Undealt hole cards and
+// board cards are in the remaining deck.
+type Secrets struct {
+	Deck   []card.Card `json:"deck"`
+	Burned []card.Card `json:"burned"`
+
+	// Cards which were pushed for the current round in external mode
+	Pushed []card.Card `json:"pushed,omitempty"`
+}
+
+func (s *Secrets) Clone() *Secrets {
+	return &Secrets{
+		Deck:   append(make([]card.Card, 0, len(s.Deck)), s.Deck...),
+		Burned: append(make([]card.Card, 0, len(s.Burned)), s.Burned...),
+		Pushed: append(make([]card.Card, 0, len(s.Pushed)), s.Pushed...),
+	}
+}
+
+// SecretStore persists secrets by game ID. Secrets are saved whenever game
+// state is updated and deleted once game is closed.
+type SecretStore interface {
+	SaveSecrets(gameID string, s *Secrets) error
+	LoadSecrets(gameID string) (*Secrets, error)
+	DeleteSecrets(gameID string) error
+}
+
+type memorySecretStore struct {
+	mu      sync.RWMutex
+	secrets map[string]*Secrets
+}
+
+// NewMemorySecretStore creates store which keeps secrets in memory
+func NewMemorySecretStore() SecretStore {
+	return &memorySecretStore{
+		secrets: make(map[string]*Secrets),
+	}
+}
+
+func (mss *memorySecretStore) SaveSecrets(gameID string, s *Secrets) error {
+
+	mss.mu.Lock()
+	defer mss.mu.Unlock()
+
+	mss.secrets[gameID] = s.Clone()
+
+	return nil
+}
+
+func (mss *memorySecretStore) LoadSecrets(gameID string) (*Secrets, error) {
+
+	mss.mu.RLock()
+	defer mss.mu.RUnlock()
+
+	s, ok := mss.secrets[gameID]
+	if !ok {
+		return nil, ErrSecretsNotFound
+	}
+
+	return s.Clone(), nil
+}
+
+func (mss *memorySecretStore) DeleteSecrets(gameID string) error {
+
+	mss.mu.Lock()
+	defer mss.mu.Unlock()
+
+	delete(mss.secrets, gameID)
+
+	return nil
+}
+
+// MarshalSecrets encodes secrets in compact binary format
+func MarshalSecrets(s *Secrets) ([]byte, error) {
+
+	e := codec.NewEncoder()
+	e.WriteHeader(codec.KindSecrets)
+	e.WriteCards(s.Deck)
+	e.WriteCards(s.Burned)
+
+	if e.Version() >= 11 {
+		e.WriteCards(s.Pushed)
+	}
+
+	return e.Bytes(), nil
+}
+
+func UnmarshalSecrets(data []byte) (*Secrets, error) {
+
+	d := codec.NewDecoder(data)
+	d.ReadHeader(codec.KindSecrets)
+
+	s := &Secrets{
+		Deck:   d.ReadCards(),
+		Burned: d.ReadCards(),
+	}
+
+	s.Pushed = make([]card.Card, 0)
+	if d.Version() >= 11 {
+		s.Pushed = d.ReadCards()
+	}
+
+	if err := d.Finish(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (g *game) GetSecrets() *Secrets {
+	return g.secrets
+}
+
+// LoadSecrets restores secrets which were saved outside of game
+func (g *game) LoadSecrets(s *Secrets) error {
+	g.secrets = s.Clone()
+	return nil
+}
+
+// absorbSecrets moves deck and burned cards of legacy state into secrets
+func (g *game) absorbSecrets() {
+
+	if len(g.gs.Meta.Deck) == 0 {
+		return
+	}
+
+	pos := g.gs.Status.CurrentDeckPosition
+	if pos > len(g.gs.Meta.Deck) {
+		pos = len(g.gs.Meta.Deck)
+	}
+
+	g.secrets = &Secrets{
+		Deck:   append(make([]card.Card, 0, len(g.gs.Meta.Deck)-pos), g.gs.Meta.Deck[pos:]...),
+		Burned: append(make([]card.Card, 0, len(g.gs.Status.Burned)), g.gs.Status.Burned...),
+		Pushed: make([]card.Card, 0),
+	}
+
+	g.gs.Meta.Deck = nil
+	g.gs.Status.Burned = nil
+}
+
+func (g *game) saveSecrets() error {
+
+	if g.store == nil || len(g.gs.GameID) == 0 {
+		return nil
+	}
+
+	// Secrets are useless after game was closed
+	if isClosedEvent(g.gs.Status.CurrentEvent) {
+		return g.store.DeleteSecrets(g.gs.GameID)
+	}
+
+	return g.store.SaveSecrets(g.gs.GameID, g.secrets)
+}
+
+// isLegacyState checks if deck is kept in state by older versions
+func isLegacyState(gs *GameState) bool {
+	return len(gs.Meta.Deck) > 0
+}
+
+// isClosedEvent checks if game is over, secrets are no longer needed
+func isClosedEvent(event string) bool {
+	return event == "GameClosed" || event == "GameVoided"
+}
@@ -69,12 +69,77 @@ func (g *game) CalculateGameResults() error {
 		}
 
 		r.UpdateScore(p.Idx, p.Combination.Power)
+		r.UpdateDescription(p.Idx, p.Combination.Description)
 	}
 
 	r.Calculate()
 
+	for _, p := range g.gs.Players {
+		if p.DeadBlind > 0 {
+			r.AddDeadBlind(p.Idx, p.DeadBlind)
+		}
+	}
+
+	g.updateDecidingKickers(r)
+
 	// Update state
 	g.gs.Result = r
 
 	return nil
 }
+
+// updateDecidingKickers finds out the kicker which made winners beat the
+// best losing hand of each pot.
+func (g *game) updateDecidingKickers(r *settlement.Result) {
+
+	powers := make(map[int]*combination.PowerState)
+	for _, p := range g.gs.Players {
+		if !p.Fold {
+			powers[p.Idx] = g.CalculatePlayerPower(p)
+		}
+	}
+
+	for potIdx, pr := range r.Pots {
+
+		if len(pr.Winners) == 0 {
+			continue
+		}
+
+		winners := make(map[int]bool)
+		for _, w := range pr.Winners {
+			winners[w.Idx] = true
+		}
+
+		// Find the best losing hand
+		var runnerUp *combination.PowerState
+		for _, l := range g.gs.Status.Pots[potIdx].Levels {
+			for _, c := range l.Contributors {
+
+				ps, ok := powers[c]
+				if !ok || winners[c] {
+					continue
+				}
+
+				if runnerUp == nil || ps.Score > runnerUp.Score {
+					runnerUp = ps
+				}
+			}
+		}
+
+		if runnerUp == nil {
+			continue
+		}
+
+		for _, w := range pr.Winners {
+
+			ps, ok := powers[w.Idx]
+			if !ok {
+				continue
+			}
+
+			if kicker, ok := combination.DecidingKicker(ps, runnerUp); ok {
+				pr.UpdateKicker(w.Idx, g.Locale().LocalizeKicker(kicker))
+			}
+		}
+	}
+}
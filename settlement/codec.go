@@ -0,0 +1,135 @@
+package settlement
+
+import (
+	"github.com/weedbox/pokerface/codec"
+)
+
+// MarshalResult encodes result in compact binary format, only exported
+// fields are encoded as JSON does.
+func MarshalResult(r *Result) ([]byte, error) {
+
+	e := codec.NewEncoder()
+	e.WriteHeader(codec.KindResult)
+	EncodeResult(e, r)
+
+	return e.Bytes(), nil
+}
+
+func UnmarshalResult(data []byte) (*Result, error) {
+
+	d := codec.NewDecoder(data)
+	d.ReadHeader(codec.KindResult)
+	r := DecodeResult(d)
+
+	if err := d.Finish(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// EncodeResult writes result which could be nil
+func EncodeResult(e *codec.Encoder, r *Result) {
+
+	e.WriteBool(r != nil)
+	if r == nil {
+		return
+	}
+
+	e.WriteLength(len(r.Players), r.Players == nil)
+	for _, p := range r.Players {
+
+		e.WriteBool(p != nil)
+		if p == nil {
+			continue
+		}
+
+		e.WriteInt(p.Idx)
+		e.WriteVarint(p.Final)
+		e.WriteVarint(p.Changed)
+		e.WriteString(p.Description)
+	}
+
+	e.WriteLength(len(r.Pots), r.Pots == nil)
+	for _, p := range r.Pots {
+
+		e.WriteBool(p != nil)
+		if p == nil {
+			continue
+		}
+
+		e.WriteVarint(p.Total)
+		e.WriteLength(len(p.Winners), p.Winners == nil)
+		for _, w := range p.Winners {
+
+			e.WriteBool(w != nil)
+			if w == nil {
+				continue
+			}
+
+			e.WriteInt(w.Idx)
+			e.WriteVarint(w.Withdraw)
+			e.WriteString(w.Kicker)
+		}
+	}
+}
+
+func DecodeResult(d *codec.Decoder) *Result {
+
+	if !d.ReadBool() {
+		return nil
+	}
+
+	r := &Result{}
+
+	if length := d.ReadLength(); length >= 0 {
+		r.Players = make([]*PlayerResult, length)
+		for i := range r.Players {
+
+			if !d.ReadBool() {
+				continue
+			}
+
+			r.Players[i] = &PlayerResult{
+				Idx:         d.ReadInt(),
+				Final:       d.ReadVarint(),
+				Changed:     d.ReadVarint(),
+				Description: d.ReadString(),
+			}
+		}
+	}
+
+	if length := d.ReadLength(); length >= 0 {
+		r.Pots = make([]*PotResult, length)
+		for i := range r.Pots {
+
+			if !d.ReadBool() {
+				continue
+			}
+
+			p := &PotResult{
+				Total: d.ReadVarint(),
+			}
+
+			if length := d.ReadLength(); length >= 0 {
+				p.Winners = make([]*Winner, length)
+				for j := range p.Winners {
+
+					if !d.ReadBool() {
+						continue
+					}
+
+					p.Winners[j] = &Winner{
+						Idx:      d.ReadInt(),
+						Withdraw: d.ReadVarint(),
+						Kicker:   d.ReadString(),
+					}
+				}
+			}
+
+			r.Pots[i] = p
+		}
+	}
+
+	return r
+}
@@ -0,0 +1,49 @@
+package settlement
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokerface/codec"
+	"github.com/weedbox/pokerface/pot"
+)
+
+func TestCodec_Result(t *testing.T) {
+
+	r := NewResult()
+	r.AddPlayer(0, 10000)
+	r.AddPlayer(1, 10000)
+	r.AddPot(4000, []*pot.Level{
+		&pot.Level{
+			Level:        2000,
+			Wager:        2000,
+			Total:        4000,
+			Contributors: []int{0, 1},
+		},
+	})
+	r.UpdateScore(0, 1000)
+	r.UpdateScore(1, 900)
+	r.UpdateDescription(0, "Pair, Kings, Ace-Nine-Two kickers")
+	r.Calculate()
+	r.Pots[0].UpdateKicker(0, "Ace kicker")
+
+	data, err := MarshalResult(r)
+	assert.Nil(t, err)
+
+	decoded, err := UnmarshalResult(data)
+	assert.Nil(t, err)
+
+	expected, _ := json.Marshal(r)
+	actual, _ := json.Marshal(decoded)
+	assert.JSONEq(t, string(expected), string(actual))
+
+	// Empty result
+	data, _ = MarshalResult(NewResult())
+	decoded, err = UnmarshalResult(data)
+	assert.Nil(t, err)
+	assert.Equal(t, NewResult(), decoded)
+
+	_, err = UnmarshalResult(data[:len(data)-1])
+	assert.Equal(t, codec.ErrUnexpectedEOF, err)
+}
@@ -9,8 +9,9 @@ type PotResult struct {
 }
 
 type Winner struct {
-	Idx      int   `json:"idx"`
-	Withdraw int64 `json:"withdraw"`
+	Idx      int    `json:"idx"`
+	Withdraw int64  `json:"withdraw"`
+	Kicker   string `json:"kicker,omitempty"`
 }
 
 func (pr *PotResult) UpdateWinner(playerIdx int, withdraw int64) {
@@ -31,3 +32,14 @@ func (pr *PotResult) UpdateWinner(playerIdx int, withdraw int64) {
 
 	return
 }
+
+// UpdateKicker sets the kicker which made player win the pot
+func (pr *PotResult) UpdateKicker(playerIdx int, kicker string) {
+
+	for _, winner := range pr.Winners {
+		if winner.Idx == playerIdx {
+			winner.Kicker = kicker
+			return
+		}
+	}
+}
@@ -10,9 +10,10 @@ type Result struct {
 }
 
 type PlayerResult struct {
-	Idx     int   `json:"idx"`
-	Final   int64 `json:"final"`
-	Changed int64 `json:"changed"`
+	Idx         int    `json:"idx"`
+	Final       int64  `json:"final"`
+	Changed     int64  `json:"changed"`
+	Description string `json:"description,omitempty"`
 }
 
 func NewResult() *Result {
@@ -57,6 +58,16 @@ func (r *Result) UpdateScore(playerIdx int, score int) {
 	}
 }
 
+func (r *Result) UpdateDescription(playerIdx int, desc string) {
+
+	for _, p := range r.Players {
+		if p.Idx == playerIdx {
+			p.Description = desc
+			return
+		}
+	}
+}
+
 func (r *Result) Update(potIdx int, playerIdx int, wager int64, withdraw int64) {
 
 	pot := r.Pots[potIdx]
@@ -76,6 +87,18 @@ func (r *Result) Update(potIdx int, playerIdx int, wager int64, withdraw int64)
 	}
 }
 
+// AddDeadBlind takes dead blind which player posted from player result
+func (r *Result) AddDeadBlind(playerIdx int, chips int64) {
+
+	for _, p := range r.Players {
+		if p.Idx == playerIdx {
+			p.Final -= chips
+			p.Changed -= chips
+			return
+		}
+	}
+}
+
 func (r *Result) CalculateWinnerRewards(potIdx int, l *LevelInfo) {
 
 	// Calculate contributer ranks of this pot by score
@@ -315,3 +315,35 @@ func TestMultipleWinnersWithRemainder(t *testing.T) {
 	assert.Equal(t, int64(555), r.Players[1].Changed)
 	assert.Equal(t, int64(-1111), r.Players[2].Changed)
 }
+
+func TestDescriptionAndKicker(t *testing.T) {
+
+	r := NewResult()
+	r.AddPlayer(0, 10000)
+	r.AddPlayer(1, 10000)
+	r.AddPot(4000, []*pot.Level{
+		&pot.Level{
+			Level:        2000,
+			Wager:        2000,
+			Total:        4000,
+			Contributors: []int{0, 1},
+		},
+	})
+
+	r.UpdateScore(0, 1000)
+	r.UpdateScore(1, 900)
+	r.UpdateDescription(0, "Pair, Kings, Ace-Nine-Two kickers")
+	r.UpdateDescription(1, "Pair, Kings, Queen-Nine-Two kickers")
+
+	r.Calculate()
+
+	r.Pots[0].UpdateKicker(0, "Ace kicker")
+	r.Pots[0].UpdateKicker(1, "Queen kicker")
+
+	assert.Equal(t, "Pair, Kings, Ace-Nine-Two kickers", r.Players[0].Description)
+	assert.Equal(t, "Pair, Kings, Queen-Nine-Two kickers", r.Players[1].Description)
+
+	// Loser is not updated
+	assert.Equal(t, 1, len(r.Pots[0].Winners))
+	assert.Equal(t, "Ace kicker", r.Pots[0].Winners[0].Kicker)
+}
@@ -18,4 +18,14 @@ type Backend interface {
 	Bet(gs *pokerface.GameState, chips int64) (*pokerface.GameState, error)
 	Raise(gs *pokerface.GameState, chipLevel int64) (*pokerface.GameState, error)
 	Pay(gs *pokerface.GameState, chips int64) (*pokerface.GameState, error)
+
+	// Operations
+	Misdeal(gs *pokerface.GameState, reason string) (*pokerface.GameState, error)
+}
+
+// SecretBackend is backend which keeps secrets of games, secrets are included
+// in snapshot of table so that the hand is able to be resumed after crash.
+type SecretBackend interface {
+	GetSecrets(gameID string) (*pokerface.Secrets, error)
+	LoadSecrets(gameID string, s *pokerface.Secrets) error
 }
@@ -0,0 +1,108 @@
+package table
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrRathole = errors.New("table: player must bring back chips which were taken away")
+)
+
+const (
+	TableMode_Tournament = "tournament"
+	TableMode_Cash       = "cash"
+)
+
+// CashOutHandler receives chips of player who left cash game. It is called
+// with lock of table, so table should not be used in it.
+type CashOutHandler func(playerID string, chips int64)
+
+type ratholeRecord struct {
+	chips  int64
+	leftAt time.Time
+}
+
+func WithCashOut(fn CashOutHandler) TableOpt {
+	return func(t *table) {
+		t.onCashOut = fn
+	}
+}
+
+// NewCashOptions returns options of cash game which has no fixed duration,
+// players can buy in between 40 and 100 big blinds.
+func NewCashOptions() *Options {
+
+	opts := NewOptions()
+	opts.Mode = TableMode_Cash
+	opts.Duration = 0
+	opts.MinBuyIn = 40 * opts.Blind.BB
+	opts.MaxBuyIn = 100 * opts.Blind.BB
+	opts.RatholeTime = 60 * 60 // one hour
+
+	return opts
+}
+
+func (t *table) isCashGame() bool {
+	return t.options.Mode == TableMode_Cash
+}
+
+// cashOut gives chips back to player who is leaving the table
+func (t *table) cashOut(p *PlayerInfo) {
+
+	if !t.isCashGame() {
+		return
+	}
+
+	if t.options.RatholeTime > 0 {
+		t.ratholes[p.ID] = &ratholeRecord{
+			chips:  p.Bankroll,
+			leftAt: time.Now(),
+		}
+	}
+
+	if t.onCashOut != nil {
+		t.onCashOut(p.ID, p.Bankroll)
+	}
+}
+
+// requiredBuyIn returns chips which player has to bring back because player
+// left the table recently.
+func (t *table) requiredBuyIn(playerID string) int64 {
+
+	r, ok := t.ratholes[playerID]
+	if !ok {
+		return 0
+	}
+
+	if time.Since(r.leftAt) >= time.Duration(t.options.RatholeTime)*time.Second {
+		delete(t.ratholes, playerID)
+		return 0
+	}
+
+	return r.chips
+}
+
+// checkBuyIn checks limits of buy-in for player who is joining the table
+func (t *table) checkBuyIn(p *PlayerInfo) error {
+
+	required := int64(0)
+	if t.isCashGame() {
+		required = t.requiredBuyIn(p.ID)
+	}
+
+	if p.Bankroll < required {
+		return ErrRathole
+	}
+
+	// Maximum doesn't apply to chips which player has to bring back
+	if t.options.MaxBuyIn > 0 && p.Bankroll > t.options.MaxBuyIn && p.Bankroll > required {
+		return ErrMaxBuyInExceeded
+	}
+
+	if p.Bankroll < t.options.MinBuyIn {
+		return ErrBelowMinBuyIn
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package table
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokerface"
+)
+
+func TestCash_Options(t *testing.T) {
+
+	opts := NewCashOptions()
+	assert.Equal(t, TableMode_Cash, opts.Mode)
+	assert.Equal(t, int64(400), opts.MinBuyIn)
+	assert.Equal(t, int64(1000), opts.MaxBuyIn)
+
+	s := newTestState()
+	s.Options = opts
+	assert.Equal(t, opts, s.Clone().Options)
+
+	// No fixed duration
+	tbl := NewTable(opts)
+	tbl.ts.EndTime = time.Now().Unix() - 1
+	assert.Nil(t, tbl.checkEndConditions())
+
+	tbl.options.Mode = TableMode_Tournament
+	assert.Equal(t, ErrTimesUp, tbl.checkEndConditions())
+}
+
+func TestCash_LeaveAndRathole(t *testing.T) {
+
+	cashOut := make(map[string]int64)
+	tbl := NewTable(NewCashOptions(), WithCashOut(func(playerID string, chips int64) {
+		cashOut[playerID] = chips
+	}))
+
+	for i := 0; i < 2; i++ {
+		_, err := tbl.Join(i, &PlayerInfo{
+			ID:       string(rune('a' + i)),
+			Bankroll: 1000,
+		})
+		assert.Nil(t, err)
+	}
+
+	a := tbl.getPlayerByID("a")
+	a.GameIdx = 0
+	tbl.getPlayerByID("b").GameIdx = 1
+
+	// Player leaves after the hand
+	tbl.ts.GameState = &pokerface.GameState{}
+	tbl.ts.GameState.Status.CurrentEvent = "RoundStarted"
+	assert.Nil(t, tbl.Leave(0))
+	assert.True(t, a.Leaving)
+	assert.Equal(t, 0, len(cashOut))
+
+	closeHand(tbl, 1500, 500)
+	assert.Nil(t, tbl.getPlayerByID("a"))
+	assert.Equal(t, map[string]int64{"a": 1500}, cashOut)
+
+	// Player comes back soon with less chips
+	_, err := tbl.Join(0, &PlayerInfo{ID: "a", Bankroll: 1000})
+	assert.Equal(t, ErrRathole, err)
+
+	// Maximum doesn't apply to chips which were taken away
+	_, err = tbl.Join(0, &PlayerInfo{ID: "a", Bankroll: 1600})
+	assert.Equal(t, ErrMaxBuyInExceeded, err)
+	_, err = tbl.Join(0, &PlayerInfo{ID: "a", Bankroll: 1500})
+	assert.Nil(t, err)
+
+	// Player leaves between hands
+	assert.Nil(t, tbl.Leave(1))
+	assert.Equal(t, int64(500), cashOut["b"])
+
+	// Rathole rule expires
+	tbl.ratholes["b"].leftAt = time.Now().Add(-2 * time.Hour)
+	_, err = tbl.Join(1, &PlayerInfo{ID: "b", Bankroll: 400})
+	assert.Nil(t, err)
+}
@@ -0,0 +1,271 @@
+package table
+
+import (
+	"sort"
+
+	"github.com/weedbox/pokerface"
+	"github.com/weedbox/pokerface/codec"
+)
+
+// MarshalState encodes table state in compact binary format, the result is
+// the same as JSON after decoding.
+func MarshalState(s *State) ([]byte, error) {
+
+	e := codec.NewEncoder()
+	e.WriteHeader(codec.KindTableState)
+	encodeState(e, s)
+
+	return e.Bytes(), nil
+}
+
+func UnmarshalState(data []byte) (*State, error) {
+
+	d := codec.NewDecoder(data)
+	d.ReadHeader(codec.KindTableState)
+	s := decodeState(d)
+
+	if err := d.Finish(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func encodeState(e *codec.Encoder, s *State) {
+
+	e.WriteBool(s != nil)
+	if s == nil {
+		return
+	}
+
+	e.WriteString(s.ID)
+	e.WriteString(s.GameType)
+	e.WriteVarint(s.StartTime)
+	e.WriteVarint(s.EndTime)
+	e.WriteString(s.Status)
+	encodeOptions(e, s.Options)
+
+	// Keys are sorted to make sure output is always the same
+	keys := make([]int, 0, len(s.Players))
+	for k := range s.Players {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+
+	e.WriteLength(len(keys), s.Players == nil)
+	for _, k := range keys {
+		e.WriteInt(k)
+		encodePlayerInfo(e, s.Players[k])
+	}
+
+	pokerface.EncodeGameState(e, s.GameState)
+
+	if e.Version() >= 6 {
+		e.WriteVarint(s.ActionDeadline)
+	}
+}
+
+func decodeState(d *codec.Decoder) *State {
+
+	if !d.ReadBool() {
+		return nil
+	}
+
+	s := &State{
+		ID:        d.ReadString(),
+		GameType:  d.ReadString(),
+		StartTime: d.ReadVarint(),
+		EndTime:   d.ReadVarint(),
+		Status:    d.ReadString(),
+		Options:   decodeOptions(d),
+	}
+
+	if length := d.ReadLength(); length >= 0 {
+		s.Players = make(map[int]*PlayerInfo, length)
+		for i := 0; i < length; i++ {
+			k := d.ReadInt()
+			s.Players[k] = decodePlayerInfo(d)
+		}
+	}
+
+	s.GameState = pokerface.DecodeGameState(d)
+
+	if d.Version() >= 6 {
+		s.ActionDeadline = d.ReadVarint()
+	}
+
+	return s
+}
+
+func encodeOptions(e *codec.Encoder, opts *Options) {
+
+	e.WriteBool(opts != nil)
+	if opts == nil {
+		return
+	}
+
+	e.WriteString(opts.GameType)
+	e.WriteInt(opts.InitialPlayers)
+	e.WriteInt(opts.MinPlayers)
+	e.WriteInt(opts.MaxSeats)
+	e.WriteInt(opts.MaxGames)
+	e.WriteInt(opts.Duration)
+	e.WriteInt(opts.Interval)
+	e.WriteInt(opts.ActionTime)
+	e.WriteBool(opts.Joinable)
+	e.WriteString(opts.EliminateMode)
+	e.WriteVarint(opts.Ante)
+	e.WriteVarint(opts.Blind.Dealer)
+	e.WriteVarint(opts.Blind.SB)
+	e.WriteVarint(opts.Blind.BB)
+
+	if e.Version() >= 7 {
+		e.WriteInt(opts.TimeBank)
+		e.WriteInt(opts.TimeBankMax)
+		e.WriteInt(opts.TimeBankTopUp)
+		e.WriteInt(opts.TimeBankTopUpHands)
+		e.WriteBool(opts.TimeBankTopUpOnLevel)
+		e.WriteBool(opts.TimeBankAutoUse)
+	}
+
+	if e.Version() >= 8 {
+		e.WriteInt(opts.MaxSitOutOrbits)
+	}
+
+	if e.Version() >= 9 {
+		e.WriteVarint(opts.MinBuyIn)
+		e.WriteVarint(opts.MaxBuyIn)
+	}
+
+	if e.Version() >= 10 {
+		e.WriteString(opts.Mode)
+		e.WriteInt(opts.RatholeTime)
+	}
+}
+
+func decodeOptions(d *codec.Decoder) *Options {
+
+	if !d.ReadBool() {
+		return nil
+	}
+
+	opts := &Options{
+		GameType:       d.ReadString(),
+		InitialPlayers: d.ReadInt(),
+		MinPlayers:     d.ReadInt(),
+		MaxSeats:       d.ReadInt(),
+		MaxGames:       d.ReadInt(),
+		Duration:       d.ReadInt(),
+		Interval:       d.ReadInt(),
+		ActionTime:     d.ReadInt(),
+		Joinable:       d.ReadBool(),
+		EliminateMode:  d.ReadString(),
+		Ante:           d.ReadVarint(),
+	}
+
+	opts.Blind.Dealer = d.ReadVarint()
+	opts.Blind.SB = d.ReadVarint()
+	opts.Blind.BB = d.ReadVarint()
+
+	if d.Version() >= 7 {
+		opts.TimeBank = d.ReadInt()
+		opts.TimeBankMax = d.ReadInt()
+		opts.TimeBankTopUp = d.ReadInt()
+		opts.TimeBankTopUpHands = d.ReadInt()
+		opts.TimeBankTopUpOnLevel = d.ReadBool()
+		opts.TimeBankAutoUse = d.ReadBool()
+	}
+
+	if d.Version() >= 8 {
+		opts.MaxSitOutOrbits = d.ReadInt()
+	}
+
+	if d.Version() >= 9 {
+		opts.MinBuyIn = d.ReadVarint()
+		opts.MaxBuyIn = d.ReadVarint()
+	}
+
+	if d.Version() >= 10 {
+		opts.Mode = d.ReadString()
+		opts.RatholeTime = d.ReadInt()
+	}
+
+	return opts
+}
+
+func encodePlayerInfo(e *codec.Encoder, p *PlayerInfo) {
+
+	e.WriteBool(p != nil)
+	if p == nil {
+		return
+	}
+
+	e.WriteString(p.ID)
+	e.WriteInt(p.SeatID)
+	e.WriteInt(p.GameIdx)
+	e.WriteStrings(p.Positions)
+	e.WriteBool(p.Playable)
+	e.WriteVarint(p.Bankroll)
+
+	if e.Version() >= 7 {
+		e.WriteVarint(p.TimeBank)
+		e.WriteVarint(p.TimeBankUsed)
+	}
+
+	if e.Version() >= 8 {
+		e.WriteBool(p.SittingOut)
+		e.WriteBool(p.WaitingForBB)
+		e.WriteBool(p.MissedSB)
+		e.WriteBool(p.MissedBB)
+		e.WriteInt(p.SitOutOrbits)
+	}
+
+	if e.Version() >= 9 {
+		e.WriteVarint(p.PendingTopUp)
+		e.WriteVarint(p.AutoTopUp)
+	}
+
+	if e.Version() >= 10 {
+		e.WriteBool(p.Leaving)
+	}
+}
+
+func decodePlayerInfo(d *codec.Decoder) *PlayerInfo {
+
+	if !d.ReadBool() {
+		return nil
+	}
+
+	p := &PlayerInfo{
+		ID:        d.ReadString(),
+		SeatID:    d.ReadInt(),
+		GameIdx:   d.ReadInt(),
+		Positions: d.ReadStrings(),
+		Playable:  d.ReadBool(),
+		Bankroll:  d.ReadVarint(),
+	}
+
+	if d.Version() >= 7 {
+		p.TimeBank = d.ReadVarint()
+		p.TimeBankUsed = d.ReadVarint()
+	}
+
+	if d.Version() >= 8 {
+		p.SittingOut = d.ReadBool()
+		p.WaitingForBB = d.ReadBool()
+		p.MissedSB = d.ReadBool()
+		p.MissedBB = d.ReadBool()
+		p.SitOutOrbits = d.ReadInt()
+	}
+
+	if d.Version() >= 9 {
+		p.PendingTopUp = d.ReadVarint()
+		p.AutoTopUp = d.ReadVarint()
+	}
+
+	if d.Version() >= 10 {
+		p.Leaving = d.ReadBool()
+	}
+
+	return p
+}
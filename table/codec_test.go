@@ -0,0 +1,91 @@
+package table
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokerface"
+)
+
+func newTestState() *State {
+
+	s := NewState()
+	s.ID = "table"
+	s.GameType = "standard"
+	s.Status = "playing"
+	s.Options = NewOptions()
+
+	for i := 0; i < 3; i++ {
+		s.Players[i] = &PlayerInfo{
+			ID:        string(rune('a' + i)),
+			SeatID:    i,
+			GameIdx:   i,
+			Positions: []string{},
+			Playable:  true,
+			Bankroll:  10000,
+		}
+	}
+
+	opts := pokerface.NewStardardGameOptions()
+	opts.Deck = pokerface.NewStandardDeckCards()
+	opts.Players = append(opts.Players,
+		&pokerface.PlayerSetting{Bankroll: 10000, Positions: []string{"dealer"}},
+		&pokerface.PlayerSetting{Bankroll: 10000, Positions: []string{"sb"}},
+		&pokerface.PlayerSetting{Bankroll: 10000, Positions: []string{"bb"}},
+	)
+
+	gs, _ := NewNativeBackend().CreateGame(opts)
+	s.GameState = gs
+
+	return s
+}
+
+func TestCodec_State(t *testing.T) {
+
+	s := newTestState()
+	s.ActionDeadline = time.Now().UnixMilli()
+
+	data, err := MarshalState(s)
+	assert.Nil(t, err)
+
+	decoded, err := UnmarshalState(data)
+	assert.Nil(t, err)
+	assert.JSONEq(t, string(s.GetJSON()), string(decoded.GetJSON()))
+
+	// Clone is based on codec
+	assert.JSONEq(t, string(s.GetJSON()), string(s.Clone().GetJSON()))
+
+	// Game state is not ready
+	s.GameState = nil
+	data, _ = MarshalState(s)
+	decoded, err = UnmarshalState(data)
+	assert.Nil(t, err)
+	assert.Nil(t, decoded.GameState)
+
+	_, err = UnmarshalState(s.GetJSON())
+	assert.NotNil(t, err)
+}
+
+func BenchmarkState_Clone_JSON(b *testing.B) {
+
+	s := newTestState()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var state State
+		data, _ := json.Marshal(s)
+		json.Unmarshal(data, &state)
+	}
+}
+
+func BenchmarkState_Clone(b *testing.B) {
+
+	s := newTestState()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Clone()
+	}
+}
@@ -0,0 +1,242 @@
+package table
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+const fileStorePattern = "events-*.log"
+
+type fileStore struct {
+	mu      sync.Mutex
+	dir     string
+	maxSize int64
+	index   int
+	size    int64
+	f       *os.File
+}
+
+// NewFileStore creates store which appends events to log files in directory,
+// an event is a line of JSON. Log is rotated to the next file once size of
+// file exceeds maxSize bytes, 0 means never.
+func NewFileStore(dir string, maxSize int64) (Store, error) {
+
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	fs := &fileStore{
+		dir:     dir,
+		maxSize: maxSize,
+	}
+
+	// Continue with the last file
+	files, err := fs.files()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(files) > 0 {
+		fmt.Sscanf(filepath.Base(files[len(files)-1]), "events-%d.log", &fs.index)
+	}
+
+	err = fs.open()
+	if err != nil {
+		return nil, err
+	}
+
+	return fs, nil
+}
+
+func (fs *fileStore) path(index int) string {
+	return filepath.Join(fs.dir, fmt.Sprintf("events-%06d.log", index))
+}
+
+// files returns log files in order they were written
+func (fs *fileStore) files() ([]string, error) {
+
+	files, err := filepath.Glob(filepath.Join(fs.dir, fileStorePattern))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(files)
+
+	return files, nil
+}
+
+func (fs *fileStore) open() error {
+
+	f, err := os.OpenFile(fs.path(fs.index), os.O_CREATE|os.O_RDWR|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	size, err := completeSize(f)
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	// Line which was not written completely by crash is dropped, otherwise the
+	// next event would be appended to it
+	err = f.Truncate(size)
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	fs.f = f
+	fs.size = size
+
+	return nil
+}
+
+// completeSize returns size of file without the last line which has no newline
+func completeSize(f *os.File) (int64, error) {
+
+	info, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+
+	buf := make([]byte, 4096)
+	end := info.Size()
+	for end > 0 {
+
+		start := end - int64(len(buf))
+		if start < 0 {
+			start = 0
+		}
+
+		n, err := f.ReadAt(buf[:end-start], start)
+		if err != nil && err != io.EOF {
+			return 0, err
+		}
+
+		for i := n - 1; i >= 0; i-- {
+			if buf[i] == '\n' {
+				return start + int64(i) + 1, nil
+			}
+		}
+
+		end = start
+	}
+
+	return 0, nil
+}
+
+func (fs *fileStore) rotate() error {
+
+	err := fs.f.Close()
+	if err != nil {
+		return err
+	}
+
+	fs.index++
+
+	return fs.open()
+}
+
+func (fs *fileStore) Append(e *Event) error {
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	data = append(data, '\n')
+
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	if fs.maxSize > 0 && fs.size > 0 && fs.size+int64(len(data)) > fs.maxSize {
+		if err := fs.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := fs.f.Write(data)
+	if err != nil {
+
+		// Partial line is not left behind
+		if n > 0 {
+			fs.f.Truncate(fs.size)
+		}
+
+		return err
+	}
+
+	fs.size += int64(n)
+
+	return nil
+}
+
+func (fs *fileStore) Events(tableID string, fn func(e *Event) error) error {
+
+	fs.mu.Lock()
+	files, err := fs.files()
+	fs.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	for _, path := range files {
+		if err := fs.readFile(path, tableID, fn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (fs *fileStore) readFile(path string, tableID string, fn func(e *Event) error) error {
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	for {
+		line, err := r.ReadBytes('\n')
+		if err == io.EOF {
+			// Line without newline was not written completely
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		var e Event
+		if err := json.Unmarshal(line, &e); err != nil {
+			return err
+		}
+
+		if e.TableID != tableID {
+			continue
+		}
+
+		if err := fn(&e); err != nil {
+			return err
+		}
+	}
+}
+
+func (fs *fileStore) Close() error {
+
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	return fs.f.Close()
+}
@@ -0,0 +1,76 @@
+package table
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileStore(t *testing.T) {
+
+	s, err := NewFileStore(t.TempDir(), 0)
+	assert.Nil(t, err)
+	defer s.Close()
+
+	testStore(t, s)
+}
+
+func TestFileStore_Rotation(t *testing.T) {
+
+	dir := t.TempDir()
+
+	// Every event is in its own file
+	s, err := NewFileStore(dir, 1)
+	assert.Nil(t, err)
+
+	for i := uint64(1); i <= 3; i++ {
+		assert.Nil(t, s.Append(newStoreTestEvent("a", i)))
+	}
+
+	assert.Nil(t, s.Close())
+
+	files, _ := filepath.Glob(filepath.Join(dir, fileStorePattern))
+	assert.Equal(t, 3, len(files))
+
+	// Log is continued after reopening
+	s, err = NewFileStore(dir, 0)
+	assert.Nil(t, err)
+	defer s.Close()
+
+	assert.Nil(t, s.Append(newStoreTestEvent("a", 4)))
+
+	files, _ = filepath.Glob(filepath.Join(dir, fileStorePattern))
+	assert.Equal(t, 3, len(files))
+
+	// Event which was not written completely is ignored
+	f, err := os.OpenFile(files[2], os.O_WRONLY|os.O_APPEND, 0644)
+	assert.Nil(t, err)
+	f.WriteString(`{"table_id":"a","seq":5`)
+	f.Close()
+
+	seq := uint64(0)
+	assert.Nil(t, s.Events("a", func(e *Event) error {
+		assert.Equal(t, seq+1, e.Seq)
+		seq = e.Seq
+		return nil
+	}))
+	assert.Equal(t, uint64(4), seq)
+
+	// Torn line is dropped once log is reopened after crash
+	assert.Nil(t, s.Close())
+	s, err = NewFileStore(dir, 0)
+	assert.Nil(t, err)
+	defer s.Close()
+
+	assert.Nil(t, s.Append(newStoreTestEvent("a", 5)))
+
+	seq = 0
+	assert.Nil(t, s.Events("a", func(e *Event) error {
+		assert.Equal(t, seq+1, e.Seq)
+		seq = e.Seq
+		return nil
+	}))
+	assert.Equal(t, uint64(5), seq)
+}
@@ -3,11 +3,12 @@ package table
 import (
 	"encoding/json"
 	"errors"
-	"fmt"
 	"sync"
+	"time"
 
 	"github.com/weedbox/pokerface"
 	"github.com/weedbox/syncsaga"
+	"github.com/weedbox/timebank"
 )
 
 var (
@@ -17,13 +18,20 @@ var (
 
 type Game interface {
 	Start() error
+	Resume() error
 	GetState() *pokerface.GameState
+	GetActionDeadline() int64
+	SetActionTime(d time.Duration)
+	ExtendActionTime(d time.Duration) error
 	OnStateUpdated(func(*pokerface.GameState))
+	OnActionTimeout(func(*pokerface.GameState) time.Duration)
+	OnError(func(error))
 
 	// Shortcut
 	ReadyForAll() error
 	PayAnte() error
 	PayBlinds() error
+	Misdeal(reason string) error
 
 	// Actions
 	Ready(playerIdx int) error
@@ -38,14 +46,21 @@ type Game interface {
 }
 
 type game struct {
-	backend        Backend
-	gs             *pokerface.GameState
-	opts           *pokerface.GameOptions
-	rg             *syncsaga.ReadyGroup
-	mu             sync.RWMutex
-	isClosed       bool
-	incomingStates chan *pokerface.GameState
-	onStateUpdated func(*pokerface.GameState)
+	backend         Backend
+	gs              *pokerface.GameState
+	opts            *pokerface.GameOptions
+	rg              *syncsaga.ReadyGroup
+	mu              sync.RWMutex
+	isClosed        bool
+	closed          chan struct{}
+	tb              *timebank.TimeBank
+	actionTime      time.Duration
+	deadline        int64
+	incomingStates  chan *pokerface.GameState
+	timeouts        chan *pokerface.GameState
+	onStateUpdated  func(*pokerface.GameState)
+	onActionTimeout func(*pokerface.GameState) time.Duration
+	onError         func(error)
 }
 
 func NewGame(backend Backend, opts *pokerface.GameOptions) *game {
@@ -54,17 +69,47 @@ func NewGame(backend Backend, opts *pokerface.GameOptions) *game {
 		backend:        backend,
 		opts:           opts,
 		rg:             syncsaga.NewReadyGroup(),
+		closed:         make(chan struct{}),
 		incomingStates: make(chan *pokerface.GameState, 1024),
+		timeouts:       make(chan *pokerface.GameState),
+		onActionTimeout: func(*pokerface.GameState) time.Duration {
+			return 0
+		},
+		onError: func(error) {},
 	}
 
 	return g
 }
 
+// NewGameFromState creates game with state which was saved before, game
+// continues from the state once Resume is called.
+func NewGameFromState(backend Backend, gs *pokerface.GameState) *game {
+
+	g := NewGame(backend, nil)
+	g.gs = gs
+
+	return g
+}
+
 func (g *game) runStateUpdater() {
 
 	go func() {
-		for state := range g.incomingStates {
-			g.handleState(state)
+		for {
+			select {
+			case state, ok := <-g.incomingStates:
+				if !ok {
+					return
+				}
+
+				g.handleState(state)
+
+			// Timeout never runs with state update at the same time
+			case gs := <-g.timeouts:
+				err := g.timeout(gs)
+				if err != nil {
+					g.onError(err)
+				}
+			}
 		}
 	}()
 }
@@ -72,14 +117,14 @@ func (g *game) runStateUpdater() {
 func (g *game) handleState(gs *pokerface.GameState) {
 
 	switch gs.Status.CurrentEvent {
-	case "GameClosed":
+	case "GameClosed", "GameVoided":
 		g.Close()
 	case "RoundClosed":
 
 		// Next round automatically
 		gs, err := g.backend.Next(gs)
 		if err != nil {
-			fmt.Println(err)
+			g.onError(err)
 			return
 		}
 
@@ -141,6 +186,8 @@ func (g *game) handleState(gs *pokerface.GameState) {
 				g.rg.Add(int64(p.Idx), false)
 			} else if gs.Meta.Blind.Dealer > 0 && gs.HasPosition(p.Idx, "dealer") {
 				g.rg.Add(int64(p.Idx), false)
+			} else if gs.Meta.Blind.BB > 0 && gs.HasPosition(p.Idx, "post") {
+				g.rg.Add(int64(p.Idx), false)
 			} else {
 				continue
 			}
@@ -154,13 +201,129 @@ func (g *game) handleState(gs *pokerface.GameState) {
 
 	//fmt.Println("Game Updated =>", g.gs.Status.CurrentEvent)
 
+	g.startTimer(gs)
 	g.onStateUpdated(gs)
 }
 
+// startTimer waits for players who are allowed to act, default actions will
+// be taken if they have no response before deadline.
+func (g *game) startTimer(gs *pokerface.GameState) {
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.stopTimer()
+
+	if g.actionTime <= 0 || g.isClosed {
+		return
+	}
+
+	waiting := false
+	for _, p := range gs.Players {
+		if len(p.AllowedActions) > 0 {
+			waiting = true
+			break
+		}
+	}
+
+	if !waiting {
+		return
+	}
+
+	g.schedule(gs, g.actionTime)
+}
+
+// schedule arms timer for state, timeout is handed to state updater. Lock
+// should be held by caller.
+func (g *game) schedule(gs *pokerface.GameState, d time.Duration) {
+
+	// Time bank is not shared between tasks, callback of task which was
+	// cancelled never sees the next one
+	g.tb = timebank.NewTimeBank()
+	g.deadline = time.Now().Add(d).UnixMilli()
+	g.tb.NewTask(d, func(isCancelled bool) {
+
+		if isCancelled {
+			return
+		}
+
+		// Timeout is never dropped, state updater skips it if it is stale
+		select {
+		case g.timeouts <- gs:
+		case <-g.closed:
+		}
+	})
+}
+
+// stopTimer should be called with lock held
+func (g *game) stopTimer() {
+
+	if g.tb != nil {
+		g.tb.Cancel()
+		g.tb = nil
+	}
+
+	g.deadline = 0
+}
+
+func (g *game) timeout(gs *pokerface.GameState) error {
+
+	// State was updated or deadline was extended already
+	g.mu.RLock()
+	expired := g.isClosed || g.gs != gs || time.Now().UnixMilli() < g.deadline
+	g.mu.RUnlock()
+
+	if expired {
+		return nil
+	}
+
+	// Everyone who didn't respond is treated as ready, the ready group
+	// completes as usual.
+	switch gs.Status.CurrentEvent {
+	case "ReadyRequested", "AnteRequested", "BlindsRequested":
+		for id := range g.rg.GetParticipantStates() {
+			g.rg.Ready(id)
+		}
+
+		return nil
+	}
+
+	// More time is given to the current player
+	if d := g.onActionTimeout(gs); d > 0 {
+		g.mu.Lock()
+		g.schedule(gs, d)
+		g.mu.Unlock()
+		g.onStateUpdated(gs)
+		return nil
+	}
+
+	idx := gs.Status.CurrentPlayer
+	if gs.HasAction(idx, "check") {
+		return g.Check(idx)
+	} else if gs.HasAction(idx, "fold") {
+		return g.Fold(idx)
+	} else if gs.HasAction(idx, "pass") {
+		return g.Pass(idx)
+	}
+
+	return nil
+}
+
 func (g *game) OnStateUpdated(fn func(*pokerface.GameState)) {
 	g.onStateUpdated = fn
 }
 
+// OnActionTimeout is called when the current player runs out of action time,
+// default action is taken if no more time is given.
+func (g *game) OnActionTimeout(fn func(*pokerface.GameState) time.Duration) {
+	g.onActionTimeout = fn
+}
+
+// OnError receives errors of actions which were taken by timer
+func (g *game) OnError(fn func(error)) {
+	g.onError = fn
+}
+
 func (g *game) Start() error {
 
 	g.runStateUpdater()
@@ -175,6 +338,21 @@ func (g *game) Start() error {
 	return nil
 }
 
+// Resume handles the state again as it was just updated, so that ready groups
+// and action timer are armed for players who are allowed to act.
+func (g *game) Resume() error {
+
+	state := g.GetState()
+	if state == nil {
+		return ErrNoRunningGame
+	}
+
+	g.runStateUpdater()
+	g.updateState(state)
+
+	return nil
+}
+
 func (g *game) cloneState(gs *pokerface.GameState) *pokerface.GameState {
 
 	// clone table state
@@ -191,8 +369,8 @@ func (g *game) cloneState(gs *pokerface.GameState) *pokerface.GameState {
 
 func (g *game) updateState(gs *pokerface.GameState) {
 
-	g.mu.RLock()
-	defer g.mu.RUnlock()
+	g.mu.Lock()
+	defer g.mu.Unlock()
 
 	state := g.cloneState(gs)
 	g.gs = state
@@ -207,30 +385,76 @@ func (g *game) updateState(gs *pokerface.GameState) {
 }
 
 func (g *game) GetState() *pokerface.GameState {
+
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
 	return g.gs
 }
 
+// GetActionDeadline returns deadline of players in unix milliseconds, it is 0
+// if nobody is acting.
+func (g *game) GetActionDeadline() int64 {
+
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.deadline
+}
+
+// SetActionTime sets time for players to act, no timer if it is 0
+func (g *game) SetActionTime(d time.Duration) {
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.actionTime = d
+}
+
+// ExtendActionTime postpones deadline of players who are acting
+func (g *game) ExtendActionTime(d time.Duration) error {
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	// Timer has fired already
+	if g.tb == nil || !g.tb.Extend(d) {
+		return ErrInvalidAction
+	}
+
+	g.deadline += d.Milliseconds()
+
+	return nil
+}
+
 func (g *game) Close() {
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
 	if g.isClosed {
 		return
 	}
 
 	g.isClosed = true
+	g.stopTimer()
+	close(g.closed)
 	close(g.incomingStates)
 }
 
 func (g *game) Ready(playerIdx int) error {
 
-	if g.gs == nil {
+	state := g.GetState()
+	if state == nil {
 		return ErrNoRunningGame
 	}
 
-	p := g.gs.GetPlayer(playerIdx)
+	p := state.GetPlayer(playerIdx)
 	if p == nil {
 		return ErrPlayerNotInGame
 	}
 
-	if !g.gs.HasAction(playerIdx, "ready") || g.rg == nil {
+	if !state.HasAction(playerIdx, "ready") || g.rg == nil {
 		return ErrInvalidAction
 	}
 
@@ -244,11 +468,12 @@ func (g *game) Ready(playerIdx int) error {
 // Shortcut
 func (g *game) ReadyForAll() error {
 
-	if g.gs == nil {
+	state := g.GetState()
+	if state == nil {
 		return ErrNoRunningGame
 	}
 
-	gs, err := g.backend.ReadyForAll(g.gs)
+	gs, err := g.backend.ReadyForAll(state)
 	if err != nil {
 		return err
 	}
@@ -260,11 +485,12 @@ func (g *game) ReadyForAll() error {
 
 func (g *game) PayAnte() error {
 
-	if g.gs == nil {
+	state := g.GetState()
+	if state == nil {
 		return ErrNoRunningGame
 	}
 
-	gs, err := g.backend.PayAnte(g.gs)
+	gs, err := g.backend.PayAnte(state)
 	if err != nil {
 		return err
 	}
@@ -276,7 +502,7 @@ func (g *game) PayAnte() error {
 
 func (g *game) PayBlinds() error {
 
-	gs, err := g.backend.PayBlinds(g.gs)
+	gs, err := g.backend.PayBlinds(g.GetState())
 	if err != nil {
 		return err
 	}
@@ -286,22 +512,43 @@ func (g *game) PayBlinds() error {
 	return nil
 }
 
+func (g *game) Misdeal(reason string) error {
+
+	state := g.GetState()
+	if state == nil {
+		return ErrNoRunningGame
+	}
+
+	gs, err := g.backend.Misdeal(state, reason)
+	if err != nil {
+		return err
+	}
+
+	// Nobody needs to be ready anymore
+	g.rg.Stop()
+
+	g.updateState(gs)
+
+	return nil
+}
+
 func (g *game) Pass(playerIdx int) error {
 
-	if g.gs == nil {
+	state := g.GetState()
+	if state == nil {
 		return ErrNoRunningGame
 	}
 
-	p := g.gs.GetPlayer(playerIdx)
+	p := state.GetPlayer(playerIdx)
 	if p == nil {
 		return ErrPlayerNotInGame
 	}
 
-	if !g.gs.HasAction(playerIdx, "pass") {
+	if !state.HasAction(playerIdx, "pass") {
 		return ErrInvalidAction
 	}
 
-	gs, err := g.backend.Pass(g.gs)
+	gs, err := g.backend.Pass(state)
 	if err != nil {
 		return err
 	}
@@ -313,21 +560,22 @@ func (g *game) Pass(playerIdx int) error {
 
 func (g *game) Pay(playerIdx int, chips int64) error {
 
-	if g.gs == nil {
+	state := g.GetState()
+	if state == nil {
 		return ErrNoRunningGame
 	}
 
-	p := g.gs.GetPlayer(playerIdx)
+	p := state.GetPlayer(playerIdx)
 	if p == nil {
 		return ErrPlayerNotInGame
 	}
 
-	if !g.gs.HasAction(playerIdx, "pay") {
+	if !state.HasAction(playerIdx, "pay") {
 		return ErrInvalidAction
 	}
 
 	// For blinds
-	switch g.gs.Status.CurrentEvent {
+	switch state.Status.CurrentEvent {
 	case "AnteRequested":
 		fallthrough
 	case "BlindsRequested":
@@ -335,7 +583,7 @@ func (g *game) Pay(playerIdx int, chips int64) error {
 		return nil
 	}
 
-	gs, err := g.backend.Pay(g.gs, chips)
+	gs, err := g.backend.Pay(state, chips)
 	if err != nil {
 		return err
 	}
@@ -347,20 +595,21 @@ func (g *game) Pay(playerIdx int, chips int64) error {
 
 func (g *game) Fold(playerIdx int) error {
 
-	if g.gs == nil {
+	state := g.GetState()
+	if state == nil {
 		return ErrNoRunningGame
 	}
 
-	p := g.gs.GetPlayer(playerIdx)
+	p := state.GetPlayer(playerIdx)
 	if p == nil {
 		return ErrPlayerNotInGame
 	}
 
-	if !g.gs.HasAction(playerIdx, "fold") {
+	if !state.HasAction(playerIdx, "fold") {
 		return ErrInvalidAction
 	}
 
-	gs, err := g.backend.Fold(g.gs)
+	gs, err := g.backend.Fold(state)
 	if err != nil {
 		return err
 	}
@@ -372,20 +621,21 @@ func (g *game) Fold(playerIdx int) error {
 
 func (g *game) Check(playerIdx int) error {
 
-	if g.gs == nil {
+	state := g.GetState()
+	if state == nil {
 		return ErrNoRunningGame
 	}
 
-	p := g.gs.GetPlayer(playerIdx)
+	p := state.GetPlayer(playerIdx)
 	if p == nil {
 		return ErrPlayerNotInGame
 	}
 
-	if !g.gs.HasAction(playerIdx, "check") {
+	if !state.HasAction(playerIdx, "check") {
 		return ErrInvalidAction
 	}
 
-	gs, err := g.backend.Check(g.gs)
+	gs, err := g.backend.Check(state)
 	if err != nil {
 		return err
 	}
@@ -397,20 +647,21 @@ func (g *game) Check(playerIdx int) error {
 
 func (g *game) Call(playerIdx int) error {
 
-	if g.gs == nil {
+	state := g.GetState()
+	if state == nil {
 		return ErrNoRunningGame
 	}
 
-	p := g.gs.GetPlayer(playerIdx)
+	p := state.GetPlayer(playerIdx)
 	if p == nil {
 		return ErrPlayerNotInGame
 	}
 
-	if !g.gs.HasAction(playerIdx, "call") {
+	if !state.HasAction(playerIdx, "call") {
 		return ErrInvalidAction
 	}
 
-	gs, err := g.backend.Call(g.gs)
+	gs, err := g.backend.Call(state)
 	if err != nil {
 		return err
 	}
@@ -422,20 +673,21 @@ func (g *game) Call(playerIdx int) error {
 
 func (g *game) Allin(playerIdx int) error {
 
-	if g.gs == nil {
+	state := g.GetState()
+	if state == nil {
 		return ErrNoRunningGame
 	}
 
-	p := g.gs.GetPlayer(playerIdx)
+	p := state.GetPlayer(playerIdx)
 	if p == nil {
 		return ErrPlayerNotInGame
 	}
 
-	if !g.gs.HasAction(playerIdx, "allin") {
+	if !state.HasAction(playerIdx, "allin") {
 		return ErrInvalidAction
 	}
 
-	gs, err := g.backend.Allin(g.gs)
+	gs, err := g.backend.Allin(state)
 	if err != nil {
 		return err
 	}
@@ -447,20 +699,21 @@ func (g *game) Allin(playerIdx int) error {
 
 func (g *game) Bet(playerIdx int, chips int64) error {
 
-	if g.gs == nil {
+	state := g.GetState()
+	if state == nil {
 		return ErrNoRunningGame
 	}
 
-	p := g.gs.GetPlayer(playerIdx)
+	p := state.GetPlayer(playerIdx)
 	if p == nil {
 		return ErrPlayerNotInGame
 	}
 
-	if !g.gs.HasAction(playerIdx, "bet") {
+	if !state.HasAction(playerIdx, "bet") {
 		return ErrInvalidAction
 	}
 
-	gs, err := g.backend.Bet(g.gs, chips)
+	gs, err := g.backend.Bet(state, chips)
 	if err != nil {
 		return err
 	}
@@ -472,20 +725,21 @@ func (g *game) Bet(playerIdx int, chips int64) error {
 
 func (g *game) Raise(playerIdx int, chipLevel int64) error {
 
-	if g.gs == nil {
+	state := g.GetState()
+	if state == nil {
 		return ErrNoRunningGame
 	}
 
-	p := g.gs.GetPlayer(playerIdx)
+	p := state.GetPlayer(playerIdx)
 	if p == nil {
 		return ErrPlayerNotInGame
 	}
 
-	if !g.gs.HasAction(playerIdx, "raise") {
+	if !state.HasAction(playerIdx, "raise") {
 		return ErrInvalidAction
 	}
 
-	gs, err := g.backend.Raise(g.gs, chipLevel)
+	gs, err := g.backend.Raise(state, chipLevel)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,187 @@
+package table
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokerface"
+)
+
+func TestGame_ActionTimer(t *testing.T) {
+
+	opts := pokerface.NewStardardGameOptions()
+	opts.Deck = pokerface.NewStandardDeckCards()
+	opts.Players = append(opts.Players,
+		&pokerface.PlayerSetting{Bankroll: 10000, Positions: []string{"dealer"}},
+		&pokerface.PlayerSetting{Bankroll: 10000, Positions: []string{"sb"}},
+		&pokerface.PlayerSetting{Bankroll: 10000, Positions: []string{"bb"}},
+	)
+
+	g := NewGame(NewNativeBackend(), opts)
+	g.SetActionTime(20 * time.Millisecond)
+
+	var mu sync.Mutex
+	events := make([]string, 0)
+	deadlines := make(map[string]int64)
+
+	done := make(chan struct{})
+	g.OnStateUpdated(func(gs *pokerface.GameState) {
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		event := gs.Status.CurrentEvent
+		events = append(events, event)
+		deadlines[event] = g.GetActionDeadline()
+
+		if event == "GameClosed" {
+			close(done)
+		}
+	})
+
+	start := time.Now()
+	assert.Nil(t, g.Start())
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("game was not closed by timer")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	// Everyone is ready and pays blinds, then players fold to big blind
+	assert.Equal(t, []string{
+		"ReadyRequested",
+		"BlindsRequested",
+		"ReadyRequested",
+		"RoundStarted",
+		"RoundStarted",
+		"RoundClosed",
+		"GameClosed",
+	}, events[len(events)-7:])
+
+	assert.True(t, deadlines["RoundStarted"] >= start.UnixMilli()+20)
+	assert.Equal(t, int64(0), deadlines["RoundClosed"])
+	assert.Equal(t, int64(0), deadlines["GameClosed"])
+
+	gs := g.GetState()
+	assert.True(t, gs.Players[0].Fold)
+	assert.True(t, gs.Players[1].Fold)
+	assert.Equal(t, int64(5), gs.Result.Players[2].Changed)
+}
+
+func TestGame_ActionTimer_Check(t *testing.T) {
+
+	opts := pokerface.NewStardardGameOptions()
+	opts.Deck = pokerface.NewStandardDeckCards()
+	opts.Players = append(opts.Players,
+		&pokerface.PlayerSetting{Bankroll: 10000, Positions: []string{"dealer", "sb"}},
+		&pokerface.PlayerSetting{Bankroll: 10000, Positions: []string{"bb"}},
+	)
+
+	g := NewGame(NewNativeBackend(), opts)
+	g.SetActionTime(20 * time.Millisecond)
+
+	called := make(chan struct{})
+	var once sync.Once
+	g.OnStateUpdated(func(gs *pokerface.GameState) {
+
+		if gs.Status.CurrentEvent != "RoundStarted" || gs.Status.CurrentPlayer != 0 {
+			return
+		}
+
+		// Small blind completes, big blind will check by timer
+		once.Do(func() {
+			go func() {
+				assert.Nil(t, g.Call(0))
+				close(called)
+			}()
+		})
+	})
+
+	assert.Nil(t, g.Start())
+
+	select {
+	case <-called:
+	case <-time.After(2 * time.Second):
+		t.Fatal("small blind did not act")
+	}
+
+	assert.Eventually(t, func() bool {
+		gs := g.GetState()
+		return gs.Status.Round == "flop"
+	}, 2*time.Second, 10*time.Millisecond)
+
+	assert.False(t, g.GetState().Players[1].Fold)
+	g.Close()
+}
+
+// brokenFoldBackend is unable to fold
+type brokenFoldBackend struct {
+	*NativeBackend
+}
+
+func (b *brokenFoldBackend) Fold(gs *pokerface.GameState) (*pokerface.GameState, error) {
+	return nil, ErrInvalidAction
+}
+
+func TestGame_ActionTimer_Error(t *testing.T) {
+
+	opts := pokerface.NewStardardGameOptions()
+	opts.Deck = pokerface.NewStandardDeckCards()
+	opts.Players = append(opts.Players,
+		&pokerface.PlayerSetting{Bankroll: 10000, Positions: []string{"dealer", "sb"}},
+		&pokerface.PlayerSetting{Bankroll: 10000, Positions: []string{"bb"}},
+	)
+
+	g := NewGame(&brokenFoldBackend{NewNativeBackend()}, opts)
+	g.SetActionTime(20 * time.Millisecond)
+	g.OnStateUpdated(func(gs *pokerface.GameState) {})
+
+	// Error of default action is reported instead of being printed
+	errs := make(chan error, 1)
+	g.OnError(func(err error) {
+		errs <- err
+	})
+
+	assert.Nil(t, g.Start())
+
+	select {
+	case err := <-errs:
+		assert.Equal(t, ErrInvalidAction, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("error was not reported")
+	}
+
+	g.Close()
+}
+
+// brokenNextBackend is unable to enter the next round
+type brokenNextBackend struct {
+	*NativeBackend
+}
+
+func (b *brokenNextBackend) Next(gs *pokerface.GameState) (*pokerface.GameState, error) {
+	return nil, ErrInvalidAction
+}
+
+func TestGame_NextRound_Error(t *testing.T) {
+
+	g := NewGame(&brokenNextBackend{NewNativeBackend()}, nil)
+	g.OnStateUpdated(func(gs *pokerface.GameState) {})
+
+	var errs []error
+	g.OnError(func(err error) {
+		errs = append(errs, err)
+	})
+
+	gs := &pokerface.GameState{}
+	gs.Status.CurrentEvent = "RoundClosed"
+	g.handleState(gs)
+
+	assert.Equal(t, []error{ErrInvalidAction}, errs)
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/weedbox/pokerface"
+	"github.com/weedbox/pokerface/patch"
 	"github.com/weedbox/pokerface/seat_manager"
 )
 
@@ -110,6 +111,8 @@ func (t *table) setupPosition() error {
 		return err
 	}
 
+	t.trackSitOut()
+
 	// Updating seat and position information for players
 	t.mu.RLock()
 	t.ts.ResetPositions()
@@ -157,7 +160,9 @@ func (t *table) updatePlayerStates(ts *State) error {
 		return nil
 	}
 
-	if ts.GameState.Status.CurrentEvent != "GameClosed" {
+	switch ts.GameState.Status.CurrentEvent {
+	case "GameClosed", "GameVoided":
+	default:
 		return nil
 	}
 
@@ -171,6 +176,15 @@ func (t *table) updatePlayerStates(ts *State) error {
 
 		p.Bankroll = rs.Final
 
+		// Player of cash game who requested to leave during the hand
+		if p.Leaving {
+			p.PendingTopUp = 0
+			t.leave(p.SeatID)
+			continue
+		}
+
+		t.applyTopUp(p)
+
 		// Not actively kicking players, waiting for requests to make players leave the table
 		if p.Bankroll == 0 {
 			t.sm.Reserve(p.SeatID)
@@ -185,11 +199,73 @@ func (t *table) updatePlayerStates(ts *State) error {
 	return nil
 }
 
-func (t *table) emitStateUpdated() {
+func (t *table) emitStateUpdated(action string) {
+
 	state := t.cloneState()
+
+	// Every update is persisted before it is broadcasted, clients keep going
+	// even if store is unavailable
+	if t.store != nil {
+		if err := t.appendEvent(state, action); err != nil {
+			t.onError(err)
+		}
+	}
+
+	// Patches are generated only if someone is listening
+	if t.onStatePatched != nil {
+		t.emitStatePatched()
+	}
+
 	t.onStateUpdated(state)
 }
 
+// emitStatePatched generates patches of redacted state for observers and every
+// player, so that private information never leaves the table. State is
+// redacted and diffed once, players get their own cards on top of it.
+func (t *table) emitStatePatched() {
+
+	viewers := map[string]*patch.Private{
+		"": nil,
+	}
+
+	ids := []string{""}
+	for _, p := range t.ts.Players {
+		viewers[p.ID] = t.getPrivateState(p.GameIdx)
+		ids = append(ids, p.ID)
+	}
+
+	// Series of players who left are dropped
+	patches, err := t.patches.Next(t.ts.Redact(-1, t.redaction), viewers)
+	if err != nil {
+		t.onError(err)
+		return
+	}
+
+	for _, id := range ids {
+		t.onStatePatched(id, patches[id])
+	}
+}
+
+// getPrivateState returns state of player in game which is hidden from others
+func (t *table) getPrivateState(idx int) *patch.Private {
+
+	gs := t.ts.GameState
+	if gs == nil || idx < 0 || (t.redaction != nil && t.redaction.Staff) {
+		return nil
+	}
+
+	for i, ps := range gs.Players {
+		if ps.Idx == idx {
+			return &patch.Private{
+				Path:  fmt.Sprintf("/game_state/players/%d", i),
+				Value: ps,
+			}
+		}
+	}
+
+	return nil
+}
+
 func (t *table) cloneState() *State {
 	return t.ts.Clone()
 }
@@ -200,8 +276,31 @@ func (t *table) updateGameState(gs *pokerface.GameState) error {
 	defer t.mu.Unlock()
 
 	t.ts.GameState = gs
+	t.settleTimeBank(gs)
+	t.ts.ActionDeadline = 0
+	if gs != nil && t.g != nil {
+		t.ts.ActionDeadline = t.g.GetActionDeadline()
+	}
+
 	t.updatePlayerStates(t.ts)
-	t.emitStateUpdated()
+
+	// Positions are renewed for the next hand, but voided hand is restarted
+	// with the same positions and not counted
+	if gs != nil {
+		switch gs.Status.CurrentEvent {
+		case "GameClosed":
+			t.inPosition = false
+		case "GameVoided":
+			t.gameCount--
+		}
+	}
+
+	action := "TableClosed"
+	if gs != nil {
+		action = gs.Status.CurrentEvent
+	}
+
+	t.emitStateUpdated(action)
 
 	return nil
 }
@@ -213,7 +312,7 @@ func (t *table) checkEndConditions() error {
 	}
 
 	// Check remaining time
-	if time.Now().Unix() >= t.ts.EndTime {
+	if !t.isCashGame() && time.Now().Unix() >= t.ts.EndTime {
 		// Times up!
 		return ErrTimesUp
 	}
@@ -226,6 +325,21 @@ func (t *table) prepareNextGame() error {
 	//t.mu.Lock()
 	//defer t.mu.Unlock()
 
+	// The hand which was interrupted is resumed before anything else
+	if t.pending != nil {
+
+		err := t.resumeGame()
+		if err != nil {
+			return err
+		}
+
+		if err := t.checkEndConditions(); err != nil {
+			return err
+		}
+
+		return t.setupPosition()
+	}
+
 	if t.isPaused {
 		return ErrGameCancelled
 	}
@@ -303,13 +417,53 @@ func (t *table) startGame() error {
 		s.Player.(*PlayerInfo).GameIdx = i
 		opts.Players = append(opts.Players, &pokerface.PlayerSetting{
 			Bankroll:  s.Player.(*PlayerInfo).Bankroll,
-			Positions: s.Player.(*PlayerInfo).Positions,
+			Positions: postMissedBlinds(s.Player.(*PlayerInfo)),
 		})
 	}
 
+	// Time bank is topped up every N hands, voided hand is not counted
+	if n := t.options.TimeBankTopUpHands; n > 0 && t.gameCount > 0 && t.gameCount%n == 0 && t.lastTopUp != t.gameCount {
+		t.mu.Lock()
+		t.topUpTimeBanks()
+		t.mu.Unlock()
+		t.lastTopUp = t.gameCount
+	}
+
 	// Create a new game with backend
 	t.g = NewGame(t.b, opts)
 
+	return t.runGame(func() error {
+
+		err := t.g.Start()
+		if err != nil {
+			return err
+		}
+
+		t.gameCount++
+
+		return nil
+	})
+}
+
+// resumeGame continues the hand which was interrupted before table was restored
+func (t *table) resumeGame() error {
+
+	gs := t.pending
+	t.pending = nil
+
+	t.g = NewGameFromState(t.b, gs)
+
+	return t.runGame(t.g.Resume)
+}
+
+func (t *table) runGame(start func() error) error {
+
+	t.g.SetActionTime(time.Duration(t.options.ActionTime) * time.Second)
+	t.g.OnActionTimeout(t.useTimeBank)
+	t.g.OnError(func(err error) {
+		t.onError(err)
+	})
+
 	// Preparing context
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -318,17 +472,17 @@ func (t *table) startGame() error {
 		//fmt.Println(gs.GameID, gs.Status.CurrentEvent)
 		t.updateGameState(gs)
 
-		if gs.Status.CurrentEvent == "GameClosed" {
+		switch gs.Status.CurrentEvent {
+		case "GameClosed", "GameVoided":
 			cancel()
 		}
 	})
 
-	err := t.g.Start()
+	err := start()
 	if err != nil {
 		return err
 	}
 
-	t.gameCount++
 	t.ts.Status = "playing"
 	/*
 		fmt.Println("startGame")
@@ -339,7 +493,5 @@ func (t *table) startGame() error {
 	// Waiting for game closed
 	<-ctx.Done()
 
-	t.inPosition = false
-
 	return nil
 }
@@ -0,0 +1,69 @@
+package table
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+)
+
+type kvStore struct {
+	kv nats.KeyValue
+}
+
+// NewKVStore creates store with key-value bucket of JetStream, which is able
+// to run with embedded NATS server. Key of event is table ID and sequence.
+func NewKVStore(kv nats.KeyValue) Store {
+	return &kvStore{
+		kv: kv,
+	}
+}
+
+func (ks *kvStore) key(e *Event) string {
+	return fmt.Sprintf("%s.%020d", e.TableID, e.Seq)
+}
+
+func (ks *kvStore) Append(e *Event) error {
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	// Event which was appended already is never overwritten
+	_, err = ks.kv.Create(ks.key(e), data)
+
+	return err
+}
+
+func (ks *kvStore) Events(tableID string, fn func(e *Event) error) error {
+
+	w, err := ks.kv.Watch(tableID+".*", nats.IgnoreDeletes())
+	if err != nil {
+		return err
+	}
+	defer w.Stop()
+
+	for entry := range w.Updates() {
+
+		// All events were received
+		if entry == nil {
+			return nil
+		}
+
+		var e Event
+		if err := json.Unmarshal(entry.Value(), &e); err != nil {
+			return err
+		}
+
+		if err := fn(&e); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (ks *kvStore) Close() error {
+	return nil
+}
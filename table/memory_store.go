@@ -0,0 +1,44 @@
+package table
+
+import "sync"
+
+type memoryStore struct {
+	mu     sync.RWMutex
+	events map[string][]*Event
+}
+
+// NewMemoryStore creates store which keeps events in memory
+func NewMemoryStore() Store {
+	return &memoryStore{
+		events: make(map[string][]*Event),
+	}
+}
+
+func (ms *memoryStore) Append(e *Event) error {
+
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	ms.events[e.TableID] = append(ms.events[e.TableID], e)
+
+	return nil
+}
+
+func (ms *memoryStore) Events(tableID string, fn func(e *Event) error) error {
+
+	ms.mu.RLock()
+	events := ms.events[tableID]
+	ms.mu.RUnlock()
+
+	for _, e := range events {
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (ms *memoryStore) Close() error {
+	return nil
+}
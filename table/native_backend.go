@@ -1,36 +1,42 @@
 package table
 
 import (
-	"encoding/json"
-
 	"github.com/weedbox/pokerface"
 )
 
 type NativeBackend struct {
 	engine pokerface.PokerFace
+	store  pokerface.SecretStore
 }
 
 func NewNativeBackend() *NativeBackend {
+
+	store := pokerface.NewMemorySecretStore()
+
 	return &NativeBackend{
-		engine: pokerface.NewPokerFace(),
+		engine: pokerface.NewPokerFace(pokerface.WithSecretStore(store)),
+		store:  store,
 	}
 }
 
 func cloneState(gs *pokerface.GameState) *pokerface.GameState {
 
 	//Note: we must clone a new structure for preventing original data of game engine is modified outside.
-	data, err := json.Marshal(gs)
+	data, err := pokerface.MarshalGameState(gs)
 	if err != nil {
 		return nil
 	}
 
-	var state pokerface.GameState
-	err = json.Unmarshal([]byte(data), &state)
+	state, err := pokerface.UnmarshalGameState(data)
 	if err != nil {
 		return nil
 	}
 
-	return &state
+	return state
+}
+
+func (nb *NativeBackend) loadGame(gs *pokerface.GameState) (pokerface.Game, error) {
+	return nb.engine.LoadGame(cloneState(gs))
 }
 
 func (nb *NativeBackend) getState(g pokerface.Game) *pokerface.GameState {
@@ -51,8 +57,12 @@ func (nb *NativeBackend) CreateGame(opts *pokerface.GameOptions) (*pokerface.Gam
 
 func (nb *NativeBackend) Next(gs *pokerface.GameState) (*pokerface.GameState, error) {
 
-	g := nb.engine.NewGameFromState(cloneState(gs))
-	err := g.Next()
+	g, err := nb.loadGame(gs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = g.Next()
 	if err != nil {
 		return nil, err
 	}
@@ -62,8 +72,12 @@ func (nb *NativeBackend) Next(gs *pokerface.GameState) (*pokerface.GameState, er
 
 func (nb *NativeBackend) ReadyForAll(gs *pokerface.GameState) (*pokerface.GameState, error) {
 
-	g := nb.engine.NewGameFromState(cloneState(gs))
-	err := g.ReadyForAll()
+	g, err := nb.loadGame(gs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = g.ReadyForAll()
 	if err != nil {
 		return nil, err
 	}
@@ -73,9 +87,12 @@ func (nb *NativeBackend) ReadyForAll(gs *pokerface.GameState) (*pokerface.GameSt
 
 func (nb *NativeBackend) Pass(gs *pokerface.GameState) (*pokerface.GameState, error) {
 
-	g := nb.engine.NewGameFromState(cloneState(gs))
+	g, err := nb.loadGame(gs)
+	if err != nil {
+		return nil, err
+	}
 
-	err := g.Pass()
+	err = g.Pass()
 	if err != nil {
 		return nil, err
 	}
@@ -85,9 +102,12 @@ func (nb *NativeBackend) Pass(gs *pokerface.GameState) (*pokerface.GameState, er
 
 func (nb *NativeBackend) PayAnte(gs *pokerface.GameState) (*pokerface.GameState, error) {
 
-	g := nb.engine.NewGameFromState(cloneState(gs))
+	g, err := nb.loadGame(gs)
+	if err != nil {
+		return nil, err
+	}
 
-	err := g.PayAnte()
+	err = g.PayAnte()
 	if err != nil {
 		return nil, err
 	}
@@ -97,9 +117,12 @@ func (nb *NativeBackend) PayAnte(gs *pokerface.GameState) (*pokerface.GameState,
 
 func (nb *NativeBackend) PayBlinds(gs *pokerface.GameState) (*pokerface.GameState, error) {
 
-	g := nb.engine.NewGameFromState(cloneState(gs))
+	g, err := nb.loadGame(gs)
+	if err != nil {
+		return nil, err
+	}
 
-	err := g.PayBlinds()
+	err = g.PayBlinds()
 	if err != nil {
 		return nil, err
 	}
@@ -109,9 +132,12 @@ func (nb *NativeBackend) PayBlinds(gs *pokerface.GameState) (*pokerface.GameStat
 
 func (nb *NativeBackend) Pay(gs *pokerface.GameState, chips int64) (*pokerface.GameState, error) {
 
-	g := nb.engine.NewGameFromState(cloneState(gs))
+	g, err := nb.loadGame(gs)
+	if err != nil {
+		return nil, err
+	}
 
-	err := g.Pay(chips)
+	err = g.Pay(chips)
 	if err != nil {
 		return nil, err
 	}
@@ -121,9 +147,12 @@ func (nb *NativeBackend) Pay(gs *pokerface.GameState, chips int64) (*pokerface.G
 
 func (nb *NativeBackend) Fold(gs *pokerface.GameState) (*pokerface.GameState, error) {
 
-	g := nb.engine.NewGameFromState(cloneState(gs))
+	g, err := nb.loadGame(gs)
+	if err != nil {
+		return nil, err
+	}
 
-	err := g.Fold()
+	err = g.Fold()
 	if err != nil {
 		return nil, err
 	}
@@ -133,9 +162,12 @@ func (nb *NativeBackend) Fold(gs *pokerface.GameState) (*pokerface.GameState, er
 
 func (nb *NativeBackend) Check(gs *pokerface.GameState) (*pokerface.GameState, error) {
 
-	g := nb.engine.NewGameFromState(cloneState(gs))
+	g, err := nb.loadGame(gs)
+	if err != nil {
+		return nil, err
+	}
 
-	err := g.Check()
+	err = g.Check()
 	if err != nil {
 		return nil, err
 	}
@@ -145,9 +177,12 @@ func (nb *NativeBackend) Check(gs *pokerface.GameState) (*pokerface.GameState, e
 
 func (nb *NativeBackend) Call(gs *pokerface.GameState) (*pokerface.GameState, error) {
 
-	g := nb.engine.NewGameFromState(cloneState(gs))
+	g, err := nb.loadGame(gs)
+	if err != nil {
+		return nil, err
+	}
 
-	err := g.Call()
+	err = g.Call()
 	if err != nil {
 		return nil, err
 	}
@@ -157,9 +192,12 @@ func (nb *NativeBackend) Call(gs *pokerface.GameState) (*pokerface.GameState, er
 
 func (nb *NativeBackend) Allin(gs *pokerface.GameState) (*pokerface.GameState, error) {
 
-	g := nb.engine.NewGameFromState(cloneState(gs))
+	g, err := nb.loadGame(gs)
+	if err != nil {
+		return nil, err
+	}
 
-	err := g.Allin()
+	err = g.Allin()
 	if err != nil {
 		return nil, err
 	}
@@ -169,9 +207,12 @@ func (nb *NativeBackend) Allin(gs *pokerface.GameState) (*pokerface.GameState, e
 
 func (nb *NativeBackend) Bet(gs *pokerface.GameState, chips int64) (*pokerface.GameState, error) {
 
-	g := nb.engine.NewGameFromState(cloneState(gs))
+	g, err := nb.loadGame(gs)
+	if err != nil {
+		return nil, err
+	}
 
-	err := g.Bet(chips)
+	err = g.Bet(chips)
 	if err != nil {
 		return nil, err
 	}
@@ -181,12 +222,38 @@ func (nb *NativeBackend) Bet(gs *pokerface.GameState, chips int64) (*pokerface.G
 
 func (nb *NativeBackend) Raise(gs *pokerface.GameState, chipLevel int64) (*pokerface.GameState, error) {
 
-	g := nb.engine.NewGameFromState(cloneState(gs))
+	g, err := nb.loadGame(gs)
+	if err != nil {
+		return nil, err
+	}
 
-	err := g.Raise(chipLevel)
+	err = g.Raise(chipLevel)
 	if err != nil {
 		return nil, err
 	}
 
 	return nb.getState(g), nil
 }
+
+func (nb *NativeBackend) Misdeal(gs *pokerface.GameState, reason string) (*pokerface.GameState, error) {
+
+	g, err := nb.loadGame(gs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = g.Misdeal(reason)
+	if err != nil {
+		return nil, err
+	}
+
+	return nb.getState(g), nil
+}
+
+func (nb *NativeBackend) GetSecrets(gameID string) (*pokerface.Secrets, error) {
+	return nb.store.LoadSecrets(gameID)
+}
+
+func (nb *NativeBackend) LoadSecrets(gameID string, s *pokerface.Secrets) error {
+	return nb.store.SaveSecrets(gameID, s)
+}
@@ -3,6 +3,7 @@ package table
 import "github.com/weedbox/pokerface"
 
 type Options struct {
+	Mode           string                 `json:"mode"`
 	GameType       string                 `json:"game_type"`
 	InitialPlayers int                    `json:"initial_players"`
 	MinPlayers     int                    `json:"min_players"`
@@ -15,10 +16,29 @@ type Options struct {
 	EliminateMode  string                 `json:"eliminate_mode"`
 	Ante           int64                  `json:"ante"`
 	Blind          pokerface.BlindSetting `json:"blind"`
+
+	// Time bank in seconds, it is disabled if no time was given
+	TimeBank             int  `json:"time_bank"`
+	TimeBankMax          int  `json:"time_bank_max"`
+	TimeBankTopUp        int  `json:"time_bank_top_up"`
+	TimeBankTopUpHands   int  `json:"time_bank_top_up_hands"`
+	TimeBankTopUpOnLevel bool `json:"time_bank_top_up_on_level"`
+	TimeBankAutoUse      bool `json:"time_bank_auto_use"`
+
+	// Player who sits out for orbits leaves the table, 0 means never
+	MaxSitOutOrbits int `json:"max_sit_out_orbits"`
+
+	// Limits of bankroll for buy-in and top-up, 0 means no limit
+	MinBuyIn int64 `json:"min_buy_in"`
+	MaxBuyIn int64 `json:"max_buy_in"`
+
+	// Player who rejoins cash game in seconds has to bring back chips
+	RatholeTime int `json:"rathole_time"`
 }
 
 func NewOptions() *Options {
 	return &Options{
+		Mode:           TableMode_Tournament,
 		GameType:       "standard",
 		InitialPlayers: 2,
 		MinPlayers:     2,
@@ -35,5 +55,15 @@ func NewOptions() *Options {
 			SB:     5,
 			BB:     10,
 		},
+		TimeBank:             0, // No time bank by default
+		TimeBankMax:          0, // unlimit by default
+		TimeBankTopUp:        0,
+		TimeBankTopUpHands:   0,
+		TimeBankTopUpOnLevel: false,
+		TimeBankAutoUse:      true,
+		MaxSitOutOrbits:      0,
+		MinBuyIn:             0,
+		MaxBuyIn:             0,
+		RatholeTime:          0,
 	}
 }
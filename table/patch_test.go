@@ -0,0 +1,105 @@
+package table
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokerface/patch"
+)
+
+func TestTable_StatePatched(t *testing.T) {
+
+	table := NewTable(NewOptions())
+
+	patches := make([]*patch.Patch, 0)
+	table.OnStatePatched(func(playerID string, p *patch.Patch) {
+		if playerID == "" {
+			patches = append(patches, p)
+		}
+	})
+
+	for i := 0; i < 3; i++ {
+		_, err := table.Join(-1, &PlayerInfo{
+			ID:       fmt.Sprintf("player_%d", i),
+			Bankroll: 10000,
+		})
+		assert.Nil(t, err)
+	}
+
+	assert.Nil(t, table.Leave(table.GetPlayerByID("player_1").SeatID))
+	assert.Equal(t, 4, len(patches))
+
+	// Client is able to rebuild state with patches
+	a := patch.NewApplier()
+	for _, p := range patches {
+		assert.Nil(t, a.Apply(p))
+	}
+
+	var s State
+	assert.Nil(t, a.Decode(&s))
+	assert.JSONEq(t, string(table.GetState().GetJSON()), string(s.GetJSON()))
+
+	// Client missed a patch
+	a = patch.NewApplier()
+	assert.Nil(t, a.Apply(patches[0]))
+	assert.Equal(t, patch.ErrSequenceGap, a.Apply(patches[2]))
+
+	snapshot, err := table.GetSnapshot("")
+	assert.Nil(t, err)
+	assert.Nil(t, a.Reset(snapshot))
+	assert.Equal(t, uint64(4), a.Seq())
+
+	data, err := a.Document()
+	assert.Nil(t, err)
+	assert.JSONEq(t, string(table.GetState().GetJSON()), string(data))
+}
+
+func TestTable_StatePatched_Redacted(t *testing.T) {
+
+	tbl := newSnapshotTestTable()
+
+	appliers := make(map[string]*patch.Applier)
+	own := 0
+	tbl.OnStatePatched(func(playerID string, p *patch.Patch) {
+
+		a, ok := appliers[playerID]
+		if !ok {
+			a = patch.NewApplier()
+			appliers[playerID] = a
+		}
+
+		assert.Nil(t, a.Apply(p))
+
+		var s State
+		assert.Nil(t, a.Decode(&s))
+
+		gs := s.GameState
+		if gs == nil || gs.Status.CurrentEvent == "GameClosed" {
+			return
+		}
+
+		// Hole cards of opponents are never sent
+		viewer := s.GetPlayerByID(playerID)
+		for _, ps := range gs.Players {
+			if viewer == nil || ps.Idx != viewer.GameIdx {
+				assert.Empty(t, ps.HoleCards, gs.Status.CurrentEvent)
+			} else if len(ps.HoleCards) > 0 {
+				own++
+			}
+		}
+	})
+
+	_, ts := playSnapshotTestTable(t, tbl, tbl.Start)
+	assertSnapshotTestResult(t, ts)
+	assert.Equal(t, 4, len(appliers))
+
+	// Players see their own cards
+	assert.NotZero(t, own)
+	snapshot, err := tbl.GetSnapshot("player_0")
+	assert.Nil(t, err)
+	assert.NotZero(t, snapshot.Seq)
+
+	_, err = tbl.GetSnapshot("unknown")
+	assert.Equal(t, patch.ErrUnknownViewer, err)
+}
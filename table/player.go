@@ -7,6 +7,24 @@ type PlayerInfo struct {
 	Positions []string `json:"positions"`
 	Playable  bool     `json:"playable"`
 	Bankroll  int64    `json:"bankroll"`
+
+	// Remaining and used time bank in milliseconds
+	TimeBank     int64 `json:"time_bank"`
+	TimeBankUsed int64 `json:"time_bank_used"`
+
+	// Player who sits out keeps seat but is not dealt in
+	SittingOut   bool `json:"sitting_out"`
+	WaitingForBB bool `json:"waiting_for_bb"`
+	MissedSB     bool `json:"missed_sb"`
+	MissedBB     bool `json:"missed_bb"`
+	SitOutOrbits int  `json:"sit_out_orbits"`
+
+	// Chips which will be added after the hand, and target of auto top-up
+	PendingTopUp int64 `json:"pending_top_up"`
+	AutoTopUp    int64 `json:"auto_top_up"`
+
+	// Player of cash game will leave the table after the hand
+	Leaving bool `json:"leaving"`
 }
 
 func (pi *PlayerInfo) CheckPosition(pos string) bool {
@@ -0,0 +1,161 @@
+package table
+
+// SitOut keeps seat for player, player will not be dealt in from the next hand
+func (t *table) SitOut(playerID string) error {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p := t.getPlayerByID(playerID)
+	if p == nil {
+		return ErrNotFoundPlayer
+	}
+
+	if p.SittingOut {
+		return nil
+	}
+
+	err := t.sm.Reserve(p.SeatID)
+	if err != nil {
+		return err
+	}
+
+	p.SittingOut = true
+	p.WaitingForBB = false
+
+	t.emitStateUpdated("PlayerSatOut")
+
+	return nil
+}
+
+// SitIn brings player back. Player who missed blinds posts a big blind to play
+// the next hand, otherwise player waits for the big blind.
+func (t *table) SitIn(playerID string, postBlinds bool) error {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p := t.getPlayerByID(playerID)
+	if p == nil {
+		return ErrNotFoundPlayer
+	}
+
+	if !p.SittingOut && !p.WaitingForBB {
+		return nil
+	}
+
+	p.SittingOut = false
+	p.SitOutOrbits = 0
+
+	// Nobody is playing, so there is no big blind to wait for
+	if t.sm.GetPlayableSeatCount() < t.options.MinPlayers {
+		p.MissedSB = false
+		p.MissedBB = false
+	}
+
+	if (p.MissedSB || p.MissedBB) && !postBlinds {
+		p.WaitingForBB = true
+		t.emitStateUpdated("PlayerSatIn")
+		return nil
+	}
+
+	p.WaitingForBB = false
+	t.emitStateUpdated("PlayerSatIn")
+
+	return t.Activate(p.SeatID)
+}
+
+// trackSitOut checks seats which were skipped by blinds of the next hand, it
+// should be called after positions were moved.
+func (t *table) trackSitOut() {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	dealer := t.sm.Dealer()
+	sb := t.sm.SmallBlind()
+	bb := t.sm.BigBlind()
+	if dealer == nil || bb == nil {
+		return
+	}
+
+	// Heads-up, dealer is small blind
+	passedSB := sb == nil || sb == dealer
+
+	seats := t.sm.GetNormalizeSeats(dealer.ID)
+	for _, s := range seats[1:] {
+
+		if s == bb {
+			break
+		}
+
+		if s == sb {
+			passedSB = true
+			continue
+		}
+
+		if s.Player == nil {
+			continue
+		}
+
+		p := s.Player.(*PlayerInfo)
+		if !p.SittingOut && !p.WaitingForBB {
+			continue
+		}
+
+		if !passedSB {
+			p.MissedSB = true
+			continue
+		}
+
+		// Big blind comes to the player who is waiting for it
+		if p.WaitingForBB {
+			t.sm.Seat(s.ID)
+			t.sm.SetBigBlind(s.ID)
+			p.WaitingForBB = false
+			p.MissedSB = false
+			p.MissedBB = false
+			break
+		}
+
+		p.MissedBB = true
+		p.SitOutOrbits++
+	}
+
+	// Player who sat out for too long leaves the table
+	if t.options.MaxSitOutOrbits <= 0 {
+		return
+	}
+
+	for _, p := range t.ts.Players {
+		if p.SittingOut && p.SitOutOrbits >= t.options.MaxSitOutOrbits {
+			t.leave(p.SeatID)
+		}
+	}
+}
+
+// postMissedBlinds returns positions of player for the next hand, player who
+// missed blinds has to post a big blind unless player is in blinds already.
+// Small blind is posted dead as well if both blinds were missed.
+func postMissedBlinds(p *PlayerInfo) []string {
+
+	if !p.MissedSB && !p.MissedBB {
+		return p.Positions
+	}
+
+	deadSB := p.MissedSB && p.MissedBB
+
+	p.MissedSB = false
+	p.MissedBB = false
+
+	if p.CheckPosition("sb") || p.CheckPosition("bb") {
+		return p.Positions
+	}
+
+	positions := append(append(make([]string, 0, len(p.Positions)+2), p.Positions...), "post")
+	if deadSB {
+		positions = append(positions, "dead_sb")
+	}
+
+	return positions
+}
@@ -0,0 +1,116 @@
+package table
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newSitOutTestTable() *table {
+
+	t := NewTable(NewOptions())
+	for i := 0; i < 4; i++ {
+		sid, _ := t.Join(i, &PlayerInfo{
+			ID:       string(rune('a' + i)),
+			Bankroll: 10000,
+		})
+		t.Activate(sid)
+	}
+
+	return t
+}
+
+func nextHand(t *table) {
+	t.sm.Next()
+	t.trackSitOut()
+}
+
+func TestSitOut_MissedBlinds(t *testing.T) {
+
+	tbl := newSitOutTestTable()
+	nextHand(tbl)
+	assert.Equal(t, 2, tbl.sm.BigBlind().ID)
+
+	assert.Nil(t, tbl.SitOut("c"))
+	c := tbl.getPlayerByID("c")
+	assert.True(t, c.SittingOut)
+	assert.True(t, tbl.sm.GetSeat(2).IsReserved)
+
+	// Small blind skips the player
+	nextHand(tbl)
+	assert.Equal(t, 3, tbl.sm.SmallBlind().ID)
+	assert.True(t, c.MissedSB)
+	assert.False(t, c.MissedBB)
+
+	nextHand(tbl)
+	assert.False(t, c.MissedBB)
+
+	// Big blind skips the player
+	nextHand(tbl)
+	assert.Equal(t, 3, tbl.sm.BigBlind().ID)
+	assert.True(t, c.MissedBB)
+	assert.Equal(t, 1, c.SitOutOrbits)
+
+	// Player waits for big blind
+	assert.Nil(t, tbl.SitIn("c", false))
+	assert.False(t, c.SittingOut)
+	assert.True(t, c.WaitingForBB)
+	assert.True(t, tbl.sm.GetSeat(2).IsReserved)
+
+	nextHand(tbl)
+	nextHand(tbl)
+	assert.True(t, c.WaitingForBB)
+
+	nextHand(tbl)
+	assert.Equal(t, 2, tbl.sm.BigBlind().ID)
+	assert.False(t, tbl.sm.GetSeat(2).IsReserved)
+	assert.False(t, c.WaitingForBB)
+	assert.False(t, c.MissedSB)
+	assert.False(t, c.MissedBB)
+}
+
+func TestSitOut_PostBlinds(t *testing.T) {
+
+	tbl := newSitOutTestTable()
+	nextHand(tbl)
+
+	assert.Nil(t, tbl.SitOut("c"))
+	nextHand(tbl)
+	nextHand(tbl)
+	nextHand(tbl)
+
+	c := tbl.getPlayerByID("c")
+	assert.True(t, c.MissedSB && c.MissedBB)
+
+	// Player posts a big blind and a dead small blind to play the next hand
+	assert.Nil(t, tbl.SitIn("c", true))
+	assert.False(t, c.WaitingForBB)
+	assert.False(t, tbl.sm.GetSeat(2).IsReserved)
+	assert.Equal(t, []string{"post", "dead_sb"}, postMissedBlinds(c))
+	assert.False(t, c.MissedSB || c.MissedBB)
+
+	// Player who missed big blind only doesn't post small blind
+	c.MissedBB = true
+	assert.Equal(t, []string{"post"}, postMissedBlinds(c))
+
+	// Player who is in blinds already doesn't post
+	c.MissedBB = true
+	c.Positions = []string{"bb"}
+	assert.Equal(t, []string{"bb"}, postMissedBlinds(c))
+}
+
+func TestSitOut_Leave(t *testing.T) {
+
+	tbl := newSitOutTestTable()
+	tbl.options.MaxSitOutOrbits = 1
+	nextHand(tbl)
+
+	assert.Nil(t, tbl.SitOut("c"))
+	for i := 0; i < 3; i++ {
+		nextHand(tbl)
+	}
+
+	assert.Nil(t, tbl.getPlayerByID("c"))
+	assert.Nil(t, tbl.sm.GetSeat(2).Player)
+	assert.Equal(t, ErrNotFoundPlayer, tbl.SitIn("c", true))
+}
@@ -0,0 +1,193 @@
+package table
+
+import (
+	"time"
+
+	"github.com/weedbox/pokerface"
+	"github.com/weedbox/pokerface/seat_manager"
+)
+
+// Snapshot is everything which is needed to recover table after crash. It
+// contains secrets of the hand, so it should never be sent to players.
+type Snapshot struct {
+	State      *State                         `json:"state"`
+	Seats      *seat_manager.SeatManagerState `json:"seats"`
+	Secrets    *pokerface.Secrets             `json:"secrets,omitempty"`
+	GameCount  int                            `json:"game_count"`
+	InPosition bool                           `json:"in_position"`
+	LastTopUp  int                            `json:"last_top_up"`
+	IsRunning  bool                           `json:"is_running"`
+	IsPaused   bool                           `json:"is_paused"`
+
+	// Sequence of the last event which was appended to store
+	Seq uint64 `json:"seq"`
+
+	// Chips which were taken away by players who left cash game
+	Ratholes map[string]*RatholeSnapshot `json:"ratholes,omitempty"`
+}
+
+type RatholeSnapshot struct {
+	Chips  int64 `json:"chips"`
+	LeftAt int64 `json:"left_at"`
+}
+
+func isGameOver(gs *pokerface.GameState) bool {
+
+	switch gs.Status.CurrentEvent {
+	case "GameClosed", "GameVoided":
+		return true
+	}
+
+	return false
+}
+
+// Snapshot captures states of table, seats and the hand in progress
+func (t *table) Snapshot() *Snapshot {
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.snapshot()
+}
+
+func (t *table) snapshot() *Snapshot {
+
+	s := &Snapshot{
+		State:      t.cloneState(),
+		Seats:      t.sm.GetStates(),
+		GameCount:  t.gameCount,
+		InPosition: t.inPosition,
+		LastTopUp:  t.lastTopUp,
+		IsRunning:  t.isRunning,
+		IsPaused:   t.isPaused,
+		Seq:        t.storeSeq,
+		Ratholes:   make(map[string]*RatholeSnapshot, len(t.ratholes)),
+	}
+
+	// Players are linked by seat when restoring, only ID is kept
+	for _, seat := range s.Seats.Seats {
+		if seat.Player != nil {
+			seat.Player = seat.Player.(*PlayerInfo).ID
+		}
+	}
+
+	for id, r := range t.ratholes {
+		s.Ratholes[id] = &RatholeSnapshot{
+			Chips:  r.chips,
+			LeftAt: r.leftAt.UnixMilli(),
+		}
+	}
+
+	// Remaining deck is required to resume the hand
+	gs := t.ts.GameState
+	if sb, ok := t.b.(SecretBackend); ok && gs != nil && !isGameOver(gs) {
+		secrets, err := sb.GetSecrets(gs.GameID)
+		if err == nil {
+			s.Secrets = secrets
+		}
+	}
+
+	return s
+}
+
+// Restore recovers table from snapshot. Table which was running is started
+// again, and the hand in progress is resumed with full action time.
+func (t *table) Restore(s *Snapshot) error {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.isRunning {
+		return ErrRunningAlready
+	}
+
+	// Events which were appended after snapshot are kept, log is continued
+	// after them
+	seq := s.Seq
+	if t.store != nil {
+
+		last, err := lastSeq(t.store, s.State.ID)
+		if err != nil {
+			return err
+		}
+
+		if last > seq {
+			seq = last
+		}
+	}
+
+	ts := s.State.Clone()
+	t.ts = ts
+	t.options = ts.Options
+	t.gameCount = s.GameCount
+	t.inPosition = s.InPosition
+	t.lastTopUp = s.LastTopUp
+	t.isPaused = s.IsPaused
+	t.storeSeq = seq
+	t.snapshotSeq = 0
+
+	// Restoring seats with players of table state
+	seats := &seat_manager.SeatManagerState{
+		Max:    s.Seats.Max,
+		Seats:  make(map[int]*seat_manager.Seat, len(s.Seats.Seats)),
+		Dealer: s.Seats.Dealer,
+		SB:     s.Seats.SB,
+		BB:     s.Seats.BB,
+	}
+
+	for id, seat := range s.Seats.Seats {
+
+		state := &seat_manager.Seat{
+			ID:         seat.ID,
+			IsActive:   seat.IsActive,
+			IsReserved: seat.IsReserved,
+		}
+
+		if p, ok := ts.Players[id]; ok && seat.Player != nil {
+			state.Player = p
+		}
+
+		seats.Seats[id] = state
+	}
+
+	t.sm = seat_manager.NewSeatManager(seats.Max)
+	err := t.sm.ApplyStates(seats)
+	if err != nil {
+		return err
+	}
+
+	t.ratholes = make(map[string]*ratholeRecord, len(s.Ratholes))
+	for id, r := range s.Ratholes {
+		t.ratholes[id] = &ratholeRecord{
+			chips:  r.Chips,
+			leftAt: time.UnixMilli(r.LeftAt),
+		}
+	}
+
+	// The hand in progress will be resumed by table loop
+	t.pending = nil
+	if gs := ts.GameState; gs != nil && !isGameOver(gs) {
+
+		if sb, ok := t.b.(SecretBackend); ok && s.Secrets != nil {
+			err := sb.LoadSecrets(gs.GameID, s.Secrets)
+			if err != nil {
+				return err
+			}
+		}
+
+		t.pending = gs
+	}
+
+	// Table which was closed is not started again
+	if !s.IsRunning || ts.Status == "closed" {
+		return nil
+	}
+
+	t.isRunning = true
+
+	go t.tableLoop()
+
+	t.NewGame(0)
+
+	return nil
+}
@@ -0,0 +1,168 @@
+package table
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newSnapshotTestTable() *table {
+
+	opts := NewOptions()
+	opts.MaxGames = 1
+	opts.Ante = 10
+
+	t := NewTable(opts, WithBackend(NewNativeBackend()))
+	for i := 0; i < 3; i++ {
+		sid, _ := t.Join(i, &PlayerInfo{
+			ID:       fmt.Sprintf("player_%d", i),
+			Bankroll: 10000,
+		})
+		t.Activate(sid)
+	}
+
+	return t
+}
+
+// playSnapshotTestTable takes actions for players until table is closed, a
+// snapshot is taken after every event.
+func playSnapshotTestTable(t *testing.T, tbl *table, start func() error) ([]*Snapshot, *State) {
+
+	states := make(chan *State, 1024)
+	tbl.OnStateUpdated(func(ts *State) {
+		states <- ts
+	})
+
+	assert.Nil(t, start())
+
+	snapshots := make([]*Snapshot, 0)
+	for {
+		var ts *State
+		select {
+		case ts = <-states:
+		case <-time.After(5 * time.Second):
+			assert.Fail(t, "table was not closed")
+			return snapshots, nil
+		}
+
+		if ts.Status == "closed" {
+			return snapshots, ts
+		}
+
+		gs := ts.GameState
+		if gs == nil {
+			continue
+		}
+
+		snapshots = append(snapshots, tbl.Snapshot())
+
+		// Actions might be rejected as state was changed already
+		switch gs.Status.CurrentEvent {
+		case "ReadyRequested":
+			for _, p := range ts.Players {
+				tbl.Ready(p.ID)
+			}
+		case "AnteRequested", "BlindsRequested":
+			for _, p := range ts.Players {
+				if gs.HasAction(p.GameIdx, "pay") {
+					tbl.Pay(p.ID, 0)
+				}
+			}
+		case "RoundStarted":
+			p := ts.GetPlayerByGameIdx(gs.Status.CurrentPlayer)
+			if gs.HasAction(p.GameIdx, "call") {
+				tbl.Call(p.ID)
+			} else {
+				tbl.Check(p.ID)
+			}
+		}
+	}
+}
+
+func assertSnapshotTestResult(t *testing.T, ts *State) {
+
+	if !assert.NotNil(t, ts) {
+		return
+	}
+
+	total := int64(0)
+	for _, p := range ts.Players {
+		total += p.Bankroll
+	}
+
+	assert.Equal(t, int64(30000), total)
+}
+
+func TestTable_SnapshotRestore(t *testing.T) {
+
+	tbl := newSnapshotTestTable()
+	snapshots, ts := playSnapshotTestTable(t, tbl, tbl.Start)
+	assertSnapshotTestResult(t, ts)
+	assert.Equal(t, 1, tbl.GetGameCount())
+	assert.NotEmpty(t, snapshots)
+
+	// Process died at every event
+	for i, s := range snapshots {
+
+		// Snapshot should survive persistence
+		data, err := json.Marshal(s)
+		assert.Nil(t, err)
+
+		var snapshot Snapshot
+		assert.Nil(t, json.Unmarshal(data, &snapshot))
+
+		restored := NewTable(NewOptions(), WithBackend(NewNativeBackend()))
+
+		// Table which was closed is not started again
+		if s.State.Status == "closed" {
+			assert.Nil(t, restored.Restore(&snapshot))
+			assert.False(t, restored.isRunning)
+			assertSnapshotTestResult(t, restored.GetState())
+			continue
+		}
+
+		_, ts := playSnapshotTestTable(t, restored, func() error {
+			return restored.Restore(&snapshot)
+		})
+
+		assertSnapshotTestResult(t, ts)
+		assert.Equal(t, 1, restored.GetGameCount(), "snapshot %d", i)
+		assert.Equal(t, s.State.ID, restored.GetState().ID)
+
+		// Nothing to be resumed if the hand was over
+		if gs := s.State.GameState; gs == nil || isGameOver(gs) {
+			assert.Nil(t, restored.GetGame())
+			continue
+		}
+
+		gs := restored.GetGame().GetState()
+		assert.Equal(t, "GameClosed", gs.Status.CurrentEvent, "snapshot %d", i)
+		assert.Equal(t, s.State.GameState.GameID, gs.GameID)
+	}
+}
+
+func TestTable_Restore_Seats(t *testing.T) {
+
+	tbl := newSnapshotTestTable()
+	assert.Nil(t, tbl.setupPosition())
+	assert.Nil(t, tbl.SitOut("player_2"))
+
+	restored := NewTable(NewOptions())
+	assert.Nil(t, restored.Restore(tbl.Snapshot()))
+	assert.Equal(t, 3, restored.sm.GetPlayerCount())
+	assert.Equal(t, tbl.sm.Dealer().ID, restored.sm.Dealer().ID)
+	assert.Equal(t, tbl.sm.BigBlind().ID, restored.sm.BigBlind().ID)
+	assert.True(t, restored.sm.GetSeat(2).IsReserved)
+	assert.Equal(t, restored.getPlayerByID("player_0"), restored.sm.GetSeat(0).Player)
+
+	// Restored table is not affected by the original one
+	tbl.getPlayerByID("player_0").Bankroll = 0
+	assert.Equal(t, int64(10000), restored.getPlayerByID("player_0").Bankroll)
+
+	// Running table can not be restored
+	restored.isRunning = true
+	assert.Equal(t, ErrRunningAlready, restored.Restore(tbl.Snapshot()))
+}
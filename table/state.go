@@ -16,6 +16,9 @@ type State struct {
 	Options   *Options             `json:"options"`
 	Players   map[int]*PlayerInfo  `json:"player"`
 	GameState *pokerface.GameState `json:"game_state"`
+
+	// Players who are allowed to act should act before deadline (unix milliseconds)
+	ActionDeadline int64 `json:"action_deadline,omitempty"`
 }
 
 func NewState() *State {
@@ -34,15 +37,32 @@ func (s *State) GetJSON() []byte {
 func (s *State) Clone() *State {
 
 	// clone table state
-	data, err := json.Marshal(s)
+	data, err := MarshalState(s)
 	if err != nil {
 		return nil
 	}
 
-	var state State
-	json.Unmarshal(data, &state)
+	state, err := UnmarshalState(data)
+	if err != nil {
+		return nil
+	}
+
+	return state
+}
+
+// Redact returns a copy of state for viewer, see GameState.Redact
+func (s *State) Redact(viewer int, policy *pokerface.RedactionPolicy) *State {
+
+	state := s.Clone()
+	if state == nil {
+		return nil
+	}
+
+	if s.GameState != nil {
+		state.GameState = s.GameState.Redact(viewer, policy)
+	}
 
-	return &state
+	return state
 }
 
 func (s *State) PrintState() error {
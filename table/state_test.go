@@ -0,0 +1,52 @@
+package table
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokerface"
+	"github.com/weedbox/pokerface/card"
+)
+
+func newRedactTestState() *State {
+
+	s := newTestState()
+	s.GameState.Players[0].HoleCards = card.MustParseCards("SA", "SK")
+	s.GameState.Players[1].HoleCards = card.MustParseCards("HA", "HK")
+	s.GameState.Players[2].HoleCards = card.MustParseCards("DA", "DK")
+
+	return s
+}
+
+func TestState_Redact(t *testing.T) {
+
+	s := newRedactTestState()
+
+	redacted := s.Redact(1, nil)
+	assert.Equal(t, 0, len(redacted.GameState.Players[0].HoleCards))
+	assert.Equal(t, 2, len(redacted.GameState.Players[1].HoleCards))
+	assert.Equal(t, 2, len(s.GameState.Players[0].HoleCards))
+
+	staff := s.Redact(pokerface.ViewerObserver, pokerface.NewStaffRedactionPolicy())
+	assert.JSONEq(t, string(s.GetJSON()), string(staff.GetJSON()))
+
+	// Game state is not ready
+	s.GameState = nil
+	assert.Nil(t, s.Redact(pokerface.ViewerObserver, nil).GameState)
+}
+
+func TestTable_GetStateAs(t *testing.T) {
+
+	tb := NewTable(NewOptions(), WithRedactionPolicy(pokerface.NewStaffRedactionPolicy()))
+	tb.ts = newRedactTestState()
+
+	assert.Equal(t, 2, len(tb.GetStateAs("b").GameState.Players[0].HoleCards))
+
+	tb = NewTable(NewOptions())
+	tb.ts = newRedactTestState()
+
+	assert.Equal(t, 0, len(tb.GetStateAs("b").GameState.Players[0].HoleCards))
+	assert.Equal(t, 2, len(tb.GetStateAs("b").GameState.Players[1].HoleCards))
+	assert.Equal(t, 0, len(tb.GetStateAs("unknown").GameState.Players[1].HoleCards))
+	assert.Equal(t, 2, len(tb.GetState().GameState.Players[0].HoleCards))
+}
@@ -0,0 +1,172 @@
+package table
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+var (
+	ErrEventNotFound     = errors.New("table: event not found")
+	ErrStateHashMismatch = errors.New("table: state hash mismatch")
+	ErrPartialRecovery   = errors.New("table: events after snapshot are not recoverable")
+)
+
+// DefaultSnapshotInterval is the maximum number of events between snapshots
+// during a hand.
+const DefaultSnapshotInterval = 50
+
+// Event is an entry of append-only log of table, it is appended whenever state
+// of table was updated. Action is name of game event or operation of table
+// which caused the update.
+//
+// Snapshot is not written with every event to keep the log small, it comes
+// with the first event of table, events which were appended while no hand was
+// in progress and every SnapshotInterval events during a hand.
+type Event struct {
+	TableID   string    `json:"table_id"`
+	Seq       uint64    `json:"seq"`
+	Action    string    `json:"action"`
+	StateHash string    `json:"state_hash"`
+	CreatedAt int64     `json:"created_at"`
+	Snapshot  *Snapshot `json:"snapshot,omitempty"`
+}
+
+// Store persists events of tables. Events of a table are read back in the
+// order they were appended. Snapshots contain secrets of the hand, so store
+// should be kept on server side only.
+type Store interface {
+	Append(e *Event) error
+	Events(tableID string, fn func(e *Event) error) error
+	Close() error
+}
+
+func WithStore(s Store) TableOpt {
+	return func(t *table) {
+		t.store = s
+	}
+}
+
+// WithSnapshotInterval sets the maximum number of events between snapshots
+// during a hand, 1 writes snapshot with every event.
+func WithSnapshotInterval(n uint64) TableOpt {
+	return func(t *table) {
+		if n > 0 {
+			t.snapshotInterval = n
+		}
+	}
+}
+
+// StateHash returns SHA-256 of state in JSON which is kept the same after state
+// was persisted.
+func StateHash(s *State) string {
+	sum := sha256.Sum256(s.GetJSON())
+	return hex.EncodeToString(sum[:])
+}
+
+// Verify checks if snapshot of event is the state which was hashed, event
+// without snapshot has nothing to be verified.
+func (e *Event) Verify() error {
+
+	if e.Snapshot == nil {
+		return nil
+	}
+
+	if StateHash(e.Snapshot.State) != e.StateHash {
+		return ErrStateHashMismatch
+	}
+
+	return nil
+}
+
+// LatestSnapshot returns the latest snapshot of table in store, table is able
+// to be recovered with it by Restore. Events carry no payload of actions, so
+// updates which were appended after the snapshot cannot be replayed. In that
+// case the snapshot is returned with ErrPartialRecovery, Seq of snapshot tells
+// where recovery stops.
+func LatestSnapshot(s Store, tableID string) (*Snapshot, error) {
+
+	var latest *Event
+	var snapshot *Snapshot
+	err := s.Events(tableID, func(e *Event) error {
+
+		latest = e
+		if e.Snapshot != nil {
+			snapshot = e.Snapshot
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if latest == nil || snapshot == nil {
+		return nil, ErrEventNotFound
+	}
+
+	if latest.Seq != snapshot.Seq {
+		return snapshot, ErrPartialRecovery
+	}
+
+	return snapshot, nil
+}
+
+// lastSeq returns sequence of the last event of table in store
+func lastSeq(s Store, tableID string) (uint64, error) {
+
+	var seq uint64
+	err := s.Events(tableID, func(e *Event) error {
+		if e.Seq > seq {
+			seq = e.Seq
+		}
+
+		return nil
+	})
+
+	return seq, err
+}
+
+// needSnapshot returns true if snapshot should be written with the next event
+func (t *table) needSnapshot(state *State) bool {
+
+	if t.snapshotSeq == 0 {
+		return true
+	}
+
+	if state.GameState == nil || isGameOver(state.GameState) {
+		return true
+	}
+
+	return t.storeSeq+1-t.snapshotSeq >= t.snapshotInterval
+}
+
+func (t *table) appendEvent(state *State, action string) error {
+
+	e := &Event{
+		TableID:   state.ID,
+		Seq:       t.storeSeq + 1,
+		Action:    action,
+		StateHash: StateHash(state),
+		CreatedAt: time.Now().UnixMilli(),
+	}
+
+	if t.needSnapshot(state) {
+		e.Snapshot = t.snapshot()
+		e.Snapshot.Seq = e.Seq
+	}
+
+	err := t.store.Append(e)
+	if err != nil {
+		return err
+	}
+
+	t.storeSeq = e.Seq
+	if e.Snapshot != nil {
+		t.snapshotSeq = e.Seq
+	}
+
+	return nil
+}
@@ -0,0 +1,229 @@
+package table
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+)
+
+func newStoreTestEvent(tableID string, seq uint64) *Event {
+
+	s := NewState()
+	s.ID = tableID
+	s.Options = NewOptions()
+
+	return &Event{
+		TableID:   tableID,
+		Seq:       seq,
+		Action:    "PlayerJoined",
+		StateHash: StateHash(s),
+		Snapshot: &Snapshot{
+			State: s,
+			Seq:   seq,
+		},
+	}
+}
+
+// testStore appends events of tables and reads them back
+func testStore(t *testing.T, s Store) {
+
+	for i := uint64(1); i <= 3; i++ {
+		assert.Nil(t, s.Append(newStoreTestEvent("a", i)))
+		assert.Nil(t, s.Append(newStoreTestEvent("b", i)))
+	}
+
+	events := make([]*Event, 0)
+	assert.Nil(t, s.Events("a", func(e *Event) error {
+		events = append(events, e)
+		return nil
+	}))
+
+	if assert.Equal(t, 3, len(events)) {
+		for i, e := range events {
+			assert.Equal(t, "a", e.TableID)
+			assert.Equal(t, uint64(i+1), e.Seq)
+			assert.Nil(t, e.Verify())
+		}
+	}
+
+	snapshot, err := LatestSnapshot(s, "b")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), snapshot.Seq)
+
+	_, err = LatestSnapshot(s, "c")
+	assert.Equal(t, ErrEventNotFound, err)
+}
+
+func TestStore_Memory(t *testing.T) {
+	testStore(t, NewMemoryStore())
+}
+
+func TestStore_KV(t *testing.T) {
+
+	s, err := server.NewServer(&server.Options{
+		Port:      -1,
+		JetStream: true,
+		StoreDir:  t.TempDir(),
+	})
+	assert.Nil(t, err)
+
+	go s.Start()
+	defer s.Shutdown()
+	assert.True(t, s.ReadyForConnections(10*time.Second))
+
+	nc, err := nats.Connect(s.ClientURL())
+	assert.Nil(t, err)
+	defer nc.Close()
+
+	js, err := nc.JetStream()
+	assert.Nil(t, err)
+
+	kv, err := js.CreateKeyValue(&nats.KeyValueConfig{
+		Bucket: "tables",
+	})
+	assert.Nil(t, err)
+
+	store := NewKVStore(kv)
+	testStore(t, store)
+
+	// Event is never overwritten
+	assert.NotNil(t, store.Append(newStoreTestEvent("a", 1)))
+}
+
+func TestStore_Table(t *testing.T) {
+
+	store := NewMemoryStore()
+
+	tbl := newSnapshotTestTable()
+	tbl.store = store
+	tbl.snapshotInterval = 5
+	_, ts := playSnapshotTestTable(t, tbl, tbl.Start)
+	assertSnapshotTestResult(t, ts)
+
+	// Every update was logged in order, snapshots are written periodically
+	var last *Event
+	var lastSnapshot uint64
+	snapshots := 0
+	assert.Nil(t, store.Events(ts.ID, func(e *Event) error {
+
+		if last != nil {
+			assert.Equal(t, last.Seq+1, e.Seq)
+		}
+
+		assert.Nil(t, e.Verify())
+		assert.LessOrEqual(t, e.Seq-lastSnapshot, uint64(5))
+		last = e
+
+		if e.Snapshot == nil {
+			assert.NotContains(t, []string{"GameClosed", "TableClosed"}, e.Action)
+			return nil
+		}
+
+		assert.Equal(t, e.Seq, e.Snapshot.Seq)
+		lastSnapshot = e.Seq
+		snapshots++
+
+		return nil
+	}))
+
+	if !assert.NotNil(t, last) {
+		return
+	}
+
+	assert.Less(t, snapshots, int(last.Seq))
+
+	assert.Equal(t, "TableClosed", last.Action)
+	assert.Equal(t, StateHash(tbl.GetState()), last.StateHash)
+
+	// Table is rebuilt with the log
+	snapshot, err := LatestSnapshot(store, ts.ID)
+	assert.Nil(t, err)
+
+	restored := NewTable(NewOptions(), WithStore(store))
+	assert.Nil(t, restored.Restore(snapshot))
+	assert.Equal(t, StateHash(tbl.GetState()), StateHash(restored.GetState()))
+
+	// Sequence is continued
+	_, err = restored.Join(-1, &PlayerInfo{
+		ID:       "player_3",
+		Bankroll: 10000,
+	})
+	assert.Nil(t, err)
+
+	snapshot, err = LatestSnapshot(store, ts.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, last.Seq+1, snapshot.Seq)
+}
+
+type failingStore struct {
+	Store
+}
+
+func (fs *failingStore) Append(e *Event) error {
+	return ErrEventNotFound
+}
+
+func TestStore_AppendError(t *testing.T) {
+
+	tbl := NewTable(NewOptions(), WithStore(&failingStore{NewMemoryStore()}))
+
+	var errs []error
+	tbl.OnError(func(err error) {
+		errs = append(errs, err)
+	})
+
+	updated := 0
+	tbl.OnStateUpdated(func(*State) {
+		updated++
+	})
+
+	// Update which was not persisted is still broadcasted
+	_, err := tbl.Join(-1, &PlayerInfo{
+		ID:       "player_1",
+		Bankroll: 10000,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []error{ErrEventNotFound}, errs)
+	assert.Equal(t, 1, updated)
+	assert.Equal(t, uint64(0), tbl.storeSeq)
+}
+
+func TestStore_PartialRecovery(t *testing.T) {
+
+	store := NewMemoryStore()
+
+	tbl := NewTable(NewOptions(), WithStore(store))
+	_, err := tbl.Join(-1, &PlayerInfo{
+		ID:       "player_0",
+		Bankroll: 10000,
+	})
+	assert.Nil(t, err)
+
+	// The last update has no snapshot
+	id := tbl.GetState().ID
+	e := newStoreTestEvent(id, 2)
+	e.Snapshot = nil
+	assert.Nil(t, store.Append(e))
+
+	snapshot, err := LatestSnapshot(store, id)
+	assert.Equal(t, ErrPartialRecovery, err)
+	assert.Equal(t, uint64(1), snapshot.Seq)
+
+	// Log is continued after events which were not recovered
+	restored := NewTable(NewOptions(), WithStore(store))
+	assert.Nil(t, restored.Restore(snapshot))
+
+	_, err = restored.Join(-1, &PlayerInfo{
+		ID:       "player_1",
+		Bankroll: 10000,
+	})
+	assert.Nil(t, err)
+
+	snapshot, err = LatestSnapshot(store, id)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), snapshot.Seq)
+	assert.Equal(t, 2, len(snapshot.State.Players))
+}
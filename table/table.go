@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/weedbox/pokerface"
+	"github.com/weedbox/pokerface/patch"
 	"github.com/weedbox/pokerface/seat_manager"
 	"github.com/weedbox/syncsaga"
 	"github.com/weedbox/timebank"
@@ -39,15 +41,21 @@ type Table interface {
 	Reserve(seatID int) error
 	Activate(seatID int) error
 	ActivateByPlayerID(playerID string) error
+	SitOut(playerID string) error
+	SitIn(playerID string, postBlinds bool) error
+	TopUp(playerID string, amount int64) error
+	SetAutoTopUp(playerID string, target int64) error
 
 	// Getter
 	GetState() *State
+	GetStateAs(playerID string) *State
 	GetGame() Game
 	GetGameCount() int
 	GetPlayablePlayerCount() int
 	GetPlayerByID(playerID string) *PlayerInfo
 	GetPlayerByGameIdx(idx int) *PlayerInfo
 	GetPlayerIdx(playerID string) int
+	GetSnapshot(playerID string) (*patch.Snapshot, error)
 
 	// Setter
 	SetAnte(chips int64)
@@ -56,6 +64,8 @@ type Table interface {
 
 	// Event
 	OnStateUpdated(func(*State))
+	OnStatePatched(func(playerID string, p *patch.Patch))
+	OnError(func(error))
 
 	// Actions
 	Ready(playerID string) error
@@ -67,23 +77,45 @@ type Table interface {
 	Allin(playerID string) error
 	Bet(playerID string, chips int64) error
 	Raise(playerID string, chipLevel int64) error
+	ExtendTime(playerID string, duration time.Duration) error
+
+	// Operations
+	Misdeal(reason string) error
+
+	// Recovery
+	Snapshot() *Snapshot
+	Restore(s *Snapshot) error
 }
 
 type table struct {
-	g              Game
-	b              Backend
-	isRunning      bool
-	isPaused       bool
-	inPosition     bool
-	options        *Options
-	gameCount      int
-	gameLoop       chan int
-	mu             sync.RWMutex
-	ts             *State
-	rg             *syncsaga.ReadyGroup
-	sm             *seat_manager.SeatManager
-	tb             *timebank.TimeBank
-	onStateUpdated func(*State)
+	g                Game
+	b                Backend
+	isRunning        bool
+	isPaused         bool
+	inPosition       bool
+	options          *Options
+	gameCount        int
+	gameLoop         chan int
+	mu               sync.RWMutex
+	ts               *State
+	rg               *syncsaga.ReadyGroup
+	sm               *seat_manager.SeatManager
+	tb               *timebank.TimeBank
+	bankTurn         *timeBankTurn
+	lastTopUp        int
+	patches          *patch.Broadcaster
+	redaction        *pokerface.RedactionPolicy
+	approveTopUp     TopUpApprover
+	onCashOut        CashOutHandler
+	ratholes         map[string]*ratholeRecord
+	pending          *pokerface.GameState
+	store            Store
+	storeSeq         uint64
+	snapshotSeq      uint64
+	snapshotInterval uint64
+	onStateUpdated   func(*State)
+	onStatePatched   func(playerID string, p *patch.Patch)
+	onError          func(error)
 }
 
 func WithBackend(b Backend) TableOpt {
@@ -92,16 +124,27 @@ func WithBackend(b Backend) TableOpt {
 	}
 }
 
+func WithRedactionPolicy(policy *pokerface.RedactionPolicy) TableOpt {
+	return func(t *table) {
+		t.redaction = policy
+	}
+}
+
 func NewTable(options *Options, opts ...TableOpt) *table {
 
 	t := &table{
-		options:        options,
-		rg:             syncsaga.NewReadyGroup(),
-		sm:             seat_manager.NewSeatManager(options.MaxSeats),
-		ts:             NewState(),
-		tb:             timebank.NewTimeBank(),
-		gameLoop:       make(chan int, 1024),
-		onStateUpdated: func(*State) {},
+		options:          options,
+		rg:               syncsaga.NewReadyGroup(),
+		sm:               seat_manager.NewSeatManager(options.MaxSeats),
+		ts:               NewState(),
+		tb:               timebank.NewTimeBank(),
+		patches:          patch.NewBroadcaster(),
+		redaction:        pokerface.NewRedactionPolicy(),
+		gameLoop:         make(chan int, 1024),
+		ratholes:         make(map[string]*ratholeRecord),
+		snapshotInterval: DefaultSnapshotInterval,
+		onStateUpdated:   func(*State) {},
+		onError:          func(error) {},
 	}
 
 	for _, opt := range opts {
@@ -154,6 +197,10 @@ func (t *table) leave(seatID int) error {
 		return err
 	}
 
+	if p, ok := t.ts.Players[seatID]; ok {
+		t.cashOut(p)
+	}
+
 	delete(t.ts.Players, seatID)
 
 	return nil
@@ -163,10 +210,44 @@ func (t *table) OnStateUpdated(fn func(*State)) {
 	t.onStateUpdated = fn
 }
 
+// OnError receives errors which happened in background, such as default
+// action taken by timer or top-up which was rejected after the hand.
+func (t *table) OnError(fn func(error)) {
+	t.onError = fn
+}
+
+// OnStatePatched receives patches of state for incremental sync, the first
+// patch has the whole state. Every player has its own series of patches which
+// is redacted for the player, player ID is empty for observers.
+func (t *table) OnStatePatched(fn func(playerID string, p *patch.Patch)) {
+	t.onStatePatched = fn
+}
+
+// GetSnapshot returns the latest patched state of player, clients should
+// resync with it if there is a gap of sequence. patch.ErrUnknownViewer is
+// returned if no patch was generated for player.
+func (t *table) GetSnapshot(playerID string) (*patch.Snapshot, error) {
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.patches.Snapshot(playerID)
+}
+
 func (t *table) GetState() *State {
 	return t.ts
 }
 
+// GetStateAs returns a copy of state for player, private information is
+// hidden by redaction policy. Unknown player is treated as observer.
+func (t *table) GetStateAs(playerID string) *State {
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.ts.Redact(t.getPlayerIdx(playerID), t.redaction)
+}
+
 func (t *table) GetGame() Game {
 	return t.g
 }
@@ -180,6 +261,16 @@ func (t *table) SetAnte(chips int64) {
 }
 
 func (t *table) SetBlinds(dealer int64, sb int64, bb int64) {
+
+	blind := t.options.Blind
+	if t.options.TimeBankTopUpOnLevel && (blind.Dealer != dealer || blind.SB != sb || blind.BB != bb) {
+
+		// New blind level
+		t.mu.Lock()
+		t.topUpTimeBanks()
+		t.mu.Unlock()
+	}
+
 	t.options.Blind.Dealer = dealer
 	t.options.Blind.SB = sb
 	t.options.Blind.BB = bb
@@ -199,6 +290,11 @@ func (t *table) Start() error {
 	t.ts.StartTime = time.Now().Unix()
 	t.ts.EndTime = t.ts.StartTime + int64(t.options.Duration)
 
+	// Cash game has no fixed duration
+	if t.isCashGame() {
+		t.ts.EndTime = 0
+	}
+
 	go t.tableLoop()
 
 	t.NewGame(0)
@@ -301,6 +397,11 @@ func (t *table) Join(seatID int, p *PlayerInfo) (int, error) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
+	// Buy-in limits
+	if err := t.checkBuyIn(p); err != nil {
+		return -1, err
+	}
+
 	// Game index is -1 by default
 	p.GameIdx = -1
 
@@ -311,9 +412,10 @@ func (t *table) Join(seatID int, p *PlayerInfo) (int, error) {
 	}
 
 	p.SeatID = sid
+	t.initTimeBank(p)
 	t.ts.Players[sid] = p
 
-	t.emitStateUpdated()
+	t.emitStateUpdated("PlayerJoined")
 
 	return sid, nil
 }
@@ -323,12 +425,19 @@ func (t *table) Leave(seatID int) error {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
+	// Player of cash game leaves with chips after the hand
+	if p, ok := t.ts.Players[seatID]; ok && t.isCashGame() && t.isInHand(p) {
+		p.Leaving = true
+		t.emitStateUpdated("PlayerLeaving")
+		return nil
+	}
+
 	err := t.leave(seatID)
 	if err != nil {
 		return err
 	}
 
-	t.emitStateUpdated()
+	t.emitStateUpdated("PlayerLeft")
 
 	return nil
 }
@@ -567,3 +676,17 @@ func (t *table) Raise(playerID string, chipLevel int64) error {
 
 	return nil
 }
+
+// Misdeal voids the current hand, the hand will be restarted with the same
+// positions.
+func (t *table) Misdeal(reason string) error {
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if !t.isRunning || t.g == nil {
+		return ErrNoRunningGame
+	}
+
+	return t.g.Misdeal(reason)
+}
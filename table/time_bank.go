@@ -0,0 +1,175 @@
+package table
+
+import (
+	"errors"
+	"time"
+
+	"github.com/weedbox/pokerface"
+)
+
+var (
+	ErrTimeBankExhausted = errors.New("table: time bank exhausted")
+)
+
+// timeBankTurn is time which was drawn from time bank for the current action,
+// the part which was not used will be returned.
+type timeBankTurn struct {
+	gs    *pokerface.GameState
+	idx   int
+	drawn time.Duration
+}
+
+func (t *table) capTimeBank(p *PlayerInfo) {
+
+	if t.options.TimeBankMax <= 0 {
+		return
+	}
+
+	max := (time.Duration(t.options.TimeBankMax) * time.Second).Milliseconds()
+	if p.TimeBank > max {
+		p.TimeBank = max
+	}
+}
+
+func (t *table) initTimeBank(p *PlayerInfo) {
+
+	if p.TimeBank > 0 || p.TimeBankUsed > 0 {
+		return
+	}
+
+	p.TimeBank = (time.Duration(t.options.TimeBank) * time.Second).Milliseconds()
+	t.capTimeBank(p)
+}
+
+func (t *table) topUpTimeBanks() {
+
+	if t.options.TimeBankTopUp <= 0 {
+		return
+	}
+
+	chunk := (time.Duration(t.options.TimeBankTopUp) * time.Second).Milliseconds()
+	for _, p := range t.ts.Players {
+		p.TimeBank += chunk
+		t.capTimeBank(p)
+	}
+}
+
+// drawTimeBank takes time from time bank of player, it returns time which was
+// taken actually.
+func (t *table) drawTimeBank(gs *pokerface.GameState, idx int, d time.Duration) time.Duration {
+
+	p := t.ts.GetPlayerByGameIdx(idx)
+	if p == nil || p.TimeBank <= 0 || d <= 0 {
+		return 0
+	}
+
+	remaining := time.Duration(p.TimeBank) * time.Millisecond
+	if d > remaining {
+		d = remaining
+	}
+
+	p.TimeBank -= d.Milliseconds()
+	p.TimeBankUsed += d.Milliseconds()
+
+	if t.bankTurn == nil || t.bankTurn.gs != gs || t.bankTurn.idx != idx {
+		t.bankTurn = &timeBankTurn{
+			gs:  gs,
+			idx: idx,
+		}
+	}
+
+	t.bankTurn.drawn += d
+
+	return d
+}
+
+// settleTimeBank returns unused time to player once action was taken
+func (t *table) settleTimeBank(gs *pokerface.GameState) {
+
+	turn := t.bankTurn
+	if turn == nil || turn.gs == gs {
+		return
+	}
+
+	t.bankTurn = nil
+
+	unused := time.Until(time.UnixMilli(t.ts.ActionDeadline))
+	if unused <= 0 {
+		return
+	}
+
+	if unused > turn.drawn {
+		unused = turn.drawn
+	}
+
+	p := t.ts.GetPlayerByGameIdx(turn.idx)
+	if p == nil {
+		return
+	}
+
+	p.TimeBank += unused.Milliseconds()
+	p.TimeBankUsed -= unused.Milliseconds()
+}
+
+// useTimeBank gives the rest of time bank to the current player who runs out
+// of action time.
+func (t *table) useTimeBank(gs *pokerface.GameState) time.Duration {
+
+	if !t.options.TimeBankAutoUse || gs.Status.CurrentEvent != "RoundStarted" {
+		return 0
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	idx := gs.Status.CurrentPlayer
+	p := t.ts.GetPlayerByGameIdx(idx)
+	if p == nil {
+		return 0
+	}
+
+	return t.drawTimeBank(gs, idx, time.Duration(p.TimeBank)*time.Millisecond)
+}
+
+// ExtendTime draws time from time bank of player who is acting
+func (t *table) ExtendTime(playerID string, duration time.Duration) error {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.isRunning || t.isPaused || t.g == nil {
+		return ErrNoRunningGame
+	}
+
+	idx := t.getPlayerIdx(playerID)
+	if idx == -1 {
+		return ErrPlayerNotInGame
+	}
+
+	gs := t.g.GetState()
+	if gs == nil || gs.Status.CurrentEvent != "RoundStarted" || gs.Status.CurrentPlayer != idx {
+		return ErrInvalidAction
+	}
+
+	d := t.drawTimeBank(gs, idx, duration)
+	if d == 0 {
+		return ErrTimeBankExhausted
+	}
+
+	err := t.g.ExtendActionTime(d)
+	if err != nil {
+
+		// Give time back to player
+		p := t.ts.GetPlayerByGameIdx(idx)
+		p.TimeBank += d.Milliseconds()
+		p.TimeBankUsed -= d.Milliseconds()
+		t.bankTurn.drawn -= d
+
+		return err
+	}
+
+	t.ts.ActionDeadline = t.g.GetActionDeadline()
+	t.emitStateUpdated("TimeExtended")
+
+	return nil
+}
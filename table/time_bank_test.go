@@ -0,0 +1,145 @@
+package table
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokerface"
+)
+
+func newTimeBankTestTable() *table {
+
+	opts := NewOptions()
+	opts.TimeBank = 10
+	opts.TimeBankMax = 15
+	opts.TimeBankTopUp = 4
+
+	t := NewTable(opts)
+	for i := 0; i < 2; i++ {
+		t.Join(-1, &PlayerInfo{
+			ID:       string(rune('a' + i)),
+			Bankroll: 10000,
+		})
+	}
+
+	t.getPlayerByID("a").GameIdx = 0
+	t.getPlayerByID("b").GameIdx = 1
+
+	return t
+}
+
+func TestTimeBank_TopUp(t *testing.T) {
+
+	tbl := newTimeBankTestTable()
+	p := tbl.getPlayerByID("a")
+	assert.Equal(t, int64(10000), p.TimeBank)
+
+	tbl.topUpTimeBanks()
+	assert.Equal(t, int64(14000), p.TimeBank)
+
+	// Capped by maximum
+	tbl.topUpTimeBanks()
+	assert.Equal(t, int64(15000), p.TimeBank)
+
+	// New blind level
+	p.TimeBank = 0
+	tbl.SetBlinds(0, 5, 10)
+	assert.Equal(t, int64(0), p.TimeBank)
+
+	tbl.options.TimeBankTopUpOnLevel = true
+	tbl.SetBlinds(0, 10, 20)
+	assert.Equal(t, int64(4000), p.TimeBank)
+	assert.Equal(t, int64(20), tbl.options.Blind.BB)
+}
+
+func TestTimeBank_DrawAndSettle(t *testing.T) {
+
+	tbl := newTimeBankTestTable()
+	p := tbl.getPlayerByGameIdx(0)
+
+	gs := &pokerface.GameState{}
+	gs.Status.CurrentEvent = "RoundStarted"
+	gs.Status.CurrentPlayer = 0
+
+	// Everything is taken for automatic use
+	assert.Equal(t, 10*time.Second, tbl.useTimeBank(gs))
+	assert.Equal(t, int64(0), p.TimeBank)
+	assert.Equal(t, int64(10000), p.TimeBankUsed)
+	assert.Equal(t, time.Duration(0), tbl.drawTimeBank(gs, 0, time.Second))
+
+	// Player acted with 4 seconds left
+	tbl.ts.ActionDeadline = time.Now().Add(4 * time.Second).UnixMilli()
+	tbl.settleTimeBank(gs)
+	assert.Equal(t, int64(10000), p.TimeBankUsed)
+
+	tbl.settleTimeBank(&pokerface.GameState{})
+	assert.InDelta(t, 4000, p.TimeBank, 100)
+	assert.InDelta(t, 6000, p.TimeBankUsed, 100)
+	assert.Nil(t, tbl.bankTurn)
+
+	// Nothing is returned after deadline
+	remaining := time.Duration(p.TimeBank) * time.Millisecond
+	assert.Equal(t, remaining, tbl.drawTimeBank(gs, 0, time.Minute))
+	tbl.ts.ActionDeadline = time.Now().Add(-time.Second).UnixMilli()
+	tbl.settleTimeBank(&pokerface.GameState{})
+	assert.Equal(t, int64(0), p.TimeBank)
+	assert.Equal(t, int64(10000), p.TimeBankUsed)
+
+	// Disabled
+	tbl.options.TimeBankAutoUse = false
+	tbl.getPlayerByGameIdx(1).TimeBank = 1000
+	gs.Status.CurrentPlayer = 1
+	assert.Equal(t, time.Duration(0), tbl.useTimeBank(gs))
+}
+
+func TestGame_ActionTimeout(t *testing.T) {
+
+	opts := pokerface.NewStardardGameOptions()
+	opts.Deck = pokerface.NewStandardDeckCards()
+	opts.Players = append(opts.Players,
+		&pokerface.PlayerSetting{Bankroll: 10000, Positions: []string{"dealer", "sb"}},
+		&pokerface.PlayerSetting{Bankroll: 10000, Positions: []string{"bb"}},
+	)
+
+	g := NewGame(NewNativeBackend(), opts)
+	g.SetActionTime(20 * time.Millisecond)
+
+	// Nothing to extend
+	assert.Equal(t, ErrInvalidAction, g.ExtendActionTime(time.Second))
+
+	extended := make(chan int64, 1)
+	g.OnActionTimeout(func(gs *pokerface.GameState) time.Duration {
+
+		if gs.Status.CurrentEvent != "RoundStarted" {
+			return 0
+		}
+
+		select {
+		case extended <- g.GetActionDeadline():
+			return 30 * time.Millisecond
+		default:
+		}
+
+		return 0
+	})
+
+	closed := make(chan struct{})
+	g.OnStateUpdated(func(gs *pokerface.GameState) {
+		if gs.Status.CurrentEvent == "GameClosed" {
+			close(closed)
+		}
+	})
+
+	assert.Nil(t, g.Start())
+
+	select {
+	case <-closed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("game was not closed by timer")
+	}
+
+	// Small blind got more time only once, then folded
+	assert.Equal(t, 1, len(extended))
+	assert.True(t, g.GetState().Players[0].Fold)
+}
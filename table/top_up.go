@@ -0,0 +1,207 @@
+package table
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrInvalidAmount     = errors.New("table: invalid amount")
+	ErrMaxBuyInExceeded  = errors.New("table: maximum buy-in exceeded")
+	ErrBelowMinBuyIn     = errors.New("table: below minimum buy-in")
+	ErrAutoTopUpTooLarge = errors.New("table: auto top-up target exceeds maximum buy-in")
+)
+
+// TopUpError is passed to error handler of table if chips which were queued
+// or topped up automatically after the hand were not added.
+type TopUpError struct {
+	PlayerID string
+	Amount   int64
+	Auto     bool
+	Err      error
+}
+
+func (e *TopUpError) Error() string {
+
+	kind := "top-up"
+	if e.Auto {
+		kind = "auto top-up"
+	}
+
+	return fmt.Sprintf("table: %s of %d for player %s rejected: %v", kind, e.Amount, e.PlayerID, e.Err)
+}
+
+func (e *TopUpError) Unwrap() error {
+	return e.Err
+}
+
+// TopUpApprover is called before chips are added to player, chips will not be
+// added if it returns error. It is called with lock of table, so table should
+// not be used in it.
+type TopUpApprover func(playerID string, amount int64) error
+
+func WithTopUpApprover(fn TopUpApprover) TableOpt {
+	return func(t *table) {
+		t.approveTopUp = fn
+	}
+}
+
+// checkTopUp returns chips which can be added to bankroll
+func (t *table) checkTopUp(bankroll int64, amount int64) (int64, error) {
+
+	if amount <= 0 {
+		return 0, ErrInvalidAmount
+	}
+
+	// Chips which are over the limit are not taken
+	if max := t.options.MaxBuyIn; max > 0 {
+
+		if bankroll >= max {
+			return 0, ErrMaxBuyInExceeded
+		}
+
+		if bankroll+amount > max {
+			amount = max - bankroll
+		}
+	}
+
+	if bankroll+amount < t.options.MinBuyIn {
+		return 0, ErrBelowMinBuyIn
+	}
+
+	return amount, nil
+}
+
+func (t *table) isInHand(p *PlayerInfo) bool {
+
+	gs := t.ts.GameState
+	if gs == nil || p.GameIdx == -1 {
+		return false
+	}
+
+	switch gs.Status.CurrentEvent {
+	case "GameClosed", "GameVoided":
+		return false
+	}
+
+	return true
+}
+
+func (t *table) addChips(p *PlayerInfo, amount int64) error {
+
+	amount, err := t.checkTopUp(p.Bankroll, amount)
+	if err != nil {
+		return err
+	}
+
+	if t.approveTopUp != nil {
+		if err := t.approveTopUp(p.ID, amount); err != nil {
+			return err
+		}
+	}
+
+	// Player who was busted gets back to the game
+	if p.Bankroll == 0 && !p.SittingOut && !p.WaitingForBB {
+		t.sm.Seat(p.SeatID)
+	}
+
+	p.Bankroll += amount
+
+	return nil
+}
+
+// applyTopUp adds chips which were requested during the hand, then tops up
+// bankroll to target of player. Rejected top-ups are reported to error handler.
+func (t *table) applyTopUp(p *PlayerInfo) {
+
+	if p.PendingTopUp > 0 {
+
+		amount := p.PendingTopUp
+		p.PendingTopUp = 0
+
+		if err := t.addChips(p, amount); err != nil {
+			t.onError(&TopUpError{
+				PlayerID: p.ID,
+				Amount:   amount,
+				Err:      err,
+			})
+		}
+	}
+
+	if p.AutoTopUp > p.Bankroll {
+
+		amount := p.AutoTopUp - p.Bankroll
+
+		if err := t.addChips(p, amount); err != nil {
+			t.onError(&TopUpError{
+				PlayerID: p.ID,
+				Amount:   amount,
+				Auto:     true,
+				Err:      err,
+			})
+		}
+	}
+}
+
+// TopUp adds chips to bankroll of player, it will be done after the hand if
+// player is playing.
+func (t *table) TopUp(playerID string, amount int64) error {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p := t.getPlayerByID(playerID)
+	if p == nil {
+		return ErrNotFoundPlayer
+	}
+
+	if !t.isInHand(p) {
+
+		if err := t.addChips(p, amount); err != nil {
+			return err
+		}
+
+		t.emitStateUpdated("ChipsAdded")
+
+		return nil
+	}
+
+	// Check limits with chips which were requested already
+	pending, err := t.checkTopUp(p.Bankroll+p.PendingTopUp, amount)
+	if err != nil {
+		return err
+	}
+
+	p.PendingTopUp += pending
+
+	t.emitStateUpdated("TopUpRequested")
+
+	return nil
+}
+
+// SetAutoTopUp sets target of bankroll which player will be topped up to after
+// every hand, 0 disables it.
+func (t *table) SetAutoTopUp(playerID string, target int64) error {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p := t.getPlayerByID(playerID)
+	if p == nil {
+		return ErrNotFoundPlayer
+	}
+
+	if target < 0 {
+		return ErrInvalidAmount
+	}
+
+	if t.options.MaxBuyIn > 0 && target > t.options.MaxBuyIn {
+		return ErrAutoTopUpTooLarge
+	}
+
+	p.AutoTopUp = target
+
+	t.emitStateUpdated("AutoTopUpUpdated")
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package table
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokerface"
+	"github.com/weedbox/pokerface/settlement"
+)
+
+func newTopUpTestTable(approver TopUpApprover) *table {
+
+	opts := NewOptions()
+	opts.MinBuyIn = 400
+	opts.MaxBuyIn = 1000
+
+	t := NewTable(opts, WithTopUpApprover(approver))
+	for i := 0; i < 2; i++ {
+		sid, _ := t.Join(i, &PlayerInfo{
+			ID:       string(rune('a' + i)),
+			Bankroll: 500,
+		})
+		t.Activate(sid)
+	}
+
+	return t
+}
+
+func closeHand(t *table, finals ...int64) {
+
+	gs := &pokerface.GameState{
+		Result: &settlement.Result{},
+	}
+	gs.Status.CurrentEvent = "GameClosed"
+
+	for idx, final := range finals {
+		gs.Result.Players = append(gs.Result.Players, &settlement.PlayerResult{
+			Idx:   idx,
+			Final: final,
+		})
+	}
+
+	t.updateGameState(gs)
+}
+
+func TestTopUp(t *testing.T) {
+
+	approved := make(map[string]int64)
+	tbl := newTopUpTestTable(func(playerID string, amount int64) error {
+		approved[playerID] += amount
+		return nil
+	})
+
+	// Limits of buy-in
+	_, err := tbl.Join(5, &PlayerInfo{ID: "x", Bankroll: 2000})
+	assert.Equal(t, ErrMaxBuyInExceeded, err)
+	_, err = tbl.Join(5, &PlayerInfo{ID: "x", Bankroll: 100})
+	assert.Equal(t, ErrBelowMinBuyIn, err)
+
+	a := tbl.getPlayerByID("a")
+	assert.Equal(t, ErrInvalidAmount, tbl.TopUp("a", 0))
+	assert.Equal(t, ErrNotFoundPlayer, tbl.TopUp("x", 100))
+
+	// Chips over the limit are not taken
+	assert.Nil(t, tbl.TopUp("a", 800))
+	assert.Equal(t, int64(1000), a.Bankroll)
+	assert.Equal(t, int64(500), approved["a"])
+	assert.Equal(t, ErrMaxBuyInExceeded, tbl.TopUp("a", 1))
+
+	// Busted player has to buy in with minimum
+	a.Bankroll = 0
+	tbl.sm.Reserve(a.SeatID)
+	assert.Equal(t, ErrBelowMinBuyIn, tbl.TopUp("a", 100))
+	assert.Nil(t, tbl.TopUp("a", 400))
+	assert.Equal(t, int64(400), a.Bankroll)
+	assert.False(t, tbl.sm.GetSeat(a.SeatID).IsReserved)
+}
+
+func TestTopUp_Queued(t *testing.T) {
+
+	reject := false
+	tbl := newTopUpTestTable(func(playerID string, amount int64) error {
+		if reject {
+			return errors.New("wallet: insufficient funds")
+		}
+		return nil
+	})
+
+	a := tbl.getPlayerByID("a")
+	b := tbl.getPlayerByID("b")
+	a.GameIdx = 0
+	b.GameIdx = 1
+
+	// Hand is in progress
+	tbl.ts.GameState = &pokerface.GameState{}
+	tbl.ts.GameState.Status.CurrentEvent = "RoundStarted"
+
+	assert.Nil(t, tbl.TopUp("a", 300))
+	assert.Nil(t, tbl.TopUp("a", 300))
+	assert.Equal(t, int64(500), a.Bankroll)
+	assert.Equal(t, int64(500), a.PendingTopUp)
+
+	assert.Nil(t, tbl.SetAutoTopUp("b", 800))
+	assert.Equal(t, ErrAutoTopUpTooLarge, tbl.SetAutoTopUp("b", 2000))
+
+	// Player a lost, b won and is topped up to target
+	closeHand(tbl, 0, 1000)
+	assert.Equal(t, int64(500), a.Bankroll)
+	assert.Equal(t, int64(0), a.PendingTopUp)
+	assert.Equal(t, int64(1000), b.Bankroll)
+	assert.False(t, tbl.sm.GetSeat(a.SeatID).IsReserved)
+
+	closeHand(tbl, 500, 300)
+	assert.Equal(t, int64(800), b.Bankroll)
+
+	// Rejected by wallet
+	var errs []error
+	tbl.OnError(func(err error) {
+		errs = append(errs, err)
+	})
+
+	reject = true
+	tbl.ts.GameState = &pokerface.GameState{}
+	assert.Nil(t, tbl.TopUp("a", 400))
+	assert.Equal(t, int64(400), a.PendingTopUp)
+
+	closeHand(tbl, 0, 300)
+	assert.Equal(t, int64(0), a.Bankroll)
+	assert.Equal(t, int64(0), a.PendingTopUp)
+	assert.True(t, tbl.sm.GetSeat(a.SeatID).IsReserved)
+
+	if assert.Len(t, errs, 2) {
+
+		var te *TopUpError
+		assert.True(t, errors.As(errs[0], &te))
+		assert.Equal(t, "a", te.PlayerID)
+		assert.Equal(t, int64(400), te.Amount)
+		assert.False(t, te.Auto)
+
+		assert.True(t, errors.As(errs[1], &te))
+		assert.Equal(t, "b", te.PlayerID)
+		assert.Equal(t, int64(500), te.Amount)
+		assert.True(t, te.Auto)
+		assert.EqualError(t, te.Err, "wallet: insufficient funds")
+	}
+}
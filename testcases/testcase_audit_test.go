@@ -0,0 +1,202 @@
+package pokerface
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokerface"
+	"github.com/weedbox/pokerface/card"
+)
+
+func auditRules(violations []pokerface.Violation) map[string]bool {
+
+	rules := make(map[string]bool)
+	for _, v := range violations {
+		rules[v.Rule] = true
+	}
+
+	return rules
+}
+
+func TestAudit_Game(t *testing.T) {
+
+	pf := pokerface.NewPokerFace(pokerface.WithAuditor(func(gs *pokerface.GameState, violations []pokerface.Violation) {
+		for _, v := range violations {
+			t.Errorf("%s: %s", gs.Status.CurrentEvent, v.String())
+		}
+	}))
+
+	opts := newSecretsTestOptions()
+	opts.Ante = 10
+
+	g := pf.NewGame(opts)
+	assert.Nil(t, g.Start())
+
+	for g.GetState().Status.CurrentEvent != "GameClosed" {
+
+		restored, err := pf.LoadGame(g.GetState().Clone())
+		assert.Nil(t, err)
+		g = restored
+		assert.Equal(t, 0, len(g.Audit()))
+
+		switch g.GetState().Status.CurrentEvent {
+		case "ReadyRequested":
+			assert.Nil(t, g.ReadyForAll())
+		case "AnteRequested":
+			assert.Nil(t, g.PayAnte())
+		case "BlindsRequested":
+			assert.Nil(t, g.PayBlinds())
+		case "RoundClosed":
+			assert.Nil(t, g.Next())
+		case "RoundStarted":
+			p := g.GetCurrentPlayer()
+			if p.CheckAction("bet") {
+				assert.Nil(t, g.Bet(100))
+			} else if p.CheckAction("check") {
+				assert.Nil(t, g.Check())
+			} else {
+				assert.Nil(t, g.Call())
+			}
+		}
+	}
+
+	assert.Equal(t, 0, len(g.Audit()))
+	assert.Equal(t, 0, len(pokerface.Audit(g.GetState())))
+}
+
+func TestAudit_Violations(t *testing.T) {
+
+	pf := pokerface.NewPokerFace()
+	g := pf.NewGame(newSecretsTestOptions())
+	assert.Nil(t, g.Start())
+	assert.Nil(t, g.ReadyForAll())
+	assert.Nil(t, g.PayBlinds())
+	assert.Nil(t, g.ReadyForAll())
+	assert.Equal(t, "RoundStarted", g.GetState().Status.CurrentEvent)
+	assert.Equal(t, 0, len(g.Audit()))
+
+	// Chips
+	gs := g.GetState().Clone()
+	gs.Players[0].StackSize++
+	assert.True(t, auditRules(pokerface.Audit(gs))[pokerface.AuditRuleChips])
+
+	// Pot of current round
+	gs = g.GetState().Clone()
+	gs.Status.CurrentRoundPot++
+	assert.True(t, auditRules(pokerface.Audit(gs))[pokerface.AuditRuleRoundPot])
+
+	// Pots
+	gs = g.GetState().Clone()
+	gs.Players[1].Pot += 10
+	gs.Players[1].InitialStackSize -= 10
+	gs.Players[1].StackSize -= 10
+	assert.Equal(t, map[string]bool{pokerface.AuditRulePots: true}, auditRules(pokerface.Audit(gs)))
+
+	// Duplicate cards
+	gs = g.GetState().Clone()
+	gs.Status.Board = append(gs.Status.Board, gs.Players[0].HoleCards[0])
+	assert.Equal(t, map[string]bool{pokerface.AuditRuleCards: true}, auditRules(pokerface.Audit(gs)))
+
+	g.GetSecrets().Deck = append(g.GetSecrets().Deck, g.GetState().Players[1].HoleCards[1])
+	violations := g.Audit()
+	assert.Equal(t, 1, len(violations))
+	assert.Equal(t, pokerface.AuditRuleCards, violations[0].Rule)
+	assert.Equal(t, -1, violations[0].Player)
+	assert.Equal(t, "cards: duplicate card "+g.GetState().Players[1].HoleCards[1].String()+" in hole cards of player 1 and deck", violations[0].String())
+
+	// Allowed actions
+	gs = g.GetState().Clone()
+	gs.Players[(gs.Status.CurrentPlayer+1)%3].AllowedActions = []string{"fold"}
+	assert.Equal(t, map[string]bool{pokerface.AuditRuleAllowedActions: true}, auditRules(pokerface.Audit(gs)))
+
+	// Auditor is called after event
+	var found []pokerface.Violation
+	g.SetAuditor(func(gs *pokerface.GameState, violations []pokerface.Violation) {
+		found = violations
+	})
+
+	g.GetState().Players[0].Bankroll += 100
+	assert.Nil(t, g.Call())
+	assert.True(t, auditRules(found)[pokerface.AuditRuleChips])
+}
+
+func TestAudit_Settlement(t *testing.T) {
+
+	pf := pokerface.NewPokerFace()
+	g := pf.NewGame(newSecretsTestOptions())
+	assert.Nil(t, g.Start())
+	assert.Nil(t, g.ReadyForAll())
+	assert.Nil(t, g.PayBlinds())
+	assert.Nil(t, g.ReadyForAll())
+
+	// Everyone folds to big blind
+	assert.Nil(t, g.Fold())
+	assert.Nil(t, g.Fold())
+	assert.Nil(t, g.Next())
+	assert.Equal(t, "GameClosed", g.GetState().Status.CurrentEvent)
+	assert.Equal(t, 0, len(g.Audit()))
+
+	gs := g.GetState().Clone()
+	gs.Result.Players[2].Changed += 5
+	gs.Result.Players[2].Final += 5
+
+	violations := pokerface.Audit(gs)
+	assert.Equal(t, 1, len(violations))
+	assert.Equal(t, "settlement: changes sum to 5", violations[0].String())
+
+	gs.Result.Players[2].Final -= 5
+	assert.Equal(t, 2, len(pokerface.Audit(gs)))
+}
+
+func TestAudit_CardsInState(t *testing.T) {
+
+	gs := &pokerface.GameState{
+		Status: pokerface.Status{
+			Round: "preflop",
+			Board: card.MustParseCards("SA", "SK"),
+		},
+		Players: []*pokerface.PlayerState{
+			{
+				Idx:       0,
+				HoleCards: card.MustParseCards("SA", "HA"),
+			},
+		},
+	}
+
+	violations := pokerface.Audit(gs)
+	assert.Equal(t, 1, len(violations))
+	assert.Equal(t, pokerface.AuditRuleCards, violations[0].Rule)
+	assert.Equal(t, 0, violations[0].Player)
+}
+
+func TestAudit_EveryEvent(t *testing.T) {
+
+	pf := pokerface.NewPokerFace()
+	g := pf.NewGame(newSecretsTestOptions())
+
+	events := make([]string, 0)
+	g.SetAuditor(func(gs *pokerface.GameState, violations []pokerface.Violation) {
+		events = append(events, gs.Status.CurrentEvent)
+	})
+
+	assert.Nil(t, g.Start())
+	assert.Nil(t, g.ReadyForAll())
+	assert.Nil(t, g.PayBlinds())
+	assert.Nil(t, g.ReadyForAll())
+
+	// Auditor is called after every event of chain, not once per action
+	events = events[:0]
+	g.GetState().Players[0].Bankroll += 100
+	assert.Nil(t, g.Fold())
+	assert.Nil(t, g.Fold())
+	assert.Nil(t, g.Next())
+	assert.Equal(t, []string{
+		"RoundStarted",
+		"RoundStarted",
+		"RoundClosed",
+		"GameCompleted",
+		"SettlementRequested",
+		"SettlementCompleted",
+		"GameClosed",
+	}, events)
+}
@@ -0,0 +1,574 @@
+package pokerface
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokerface"
+	"github.com/weedbox/pokerface/scenario"
+)
+
+// Betting harness plays random legal actions which are picked from
+// GetAvailableActions, illegal actions are tried in between and they must be
+// rejected without changing state. A failing case is shrunk and printed as
+// scenario.
+
+var bettingLimits = []string{"no", "pot"}
+
+const bettingMaxSteps = 1000
+
+type bettingCase struct {
+	Limit   string
+	Ante    int64
+	SB      int64
+	BB      int64
+	Stacks  []int64
+	Choices []int
+}
+
+type bettingFailure struct {
+	Kind    string // panic, error, illegal, progress, invariant, property
+	Step    int
+	Message string
+}
+
+func (f *bettingFailure) String() string {
+	return fmt.Sprintf("%s at step %d: %s", f.Kind, f.Step, f.Message)
+}
+
+type bettingRun struct {
+	Steps   []string
+	Failure *bettingFailure
+}
+
+func newBettingCase(r *rand.Rand) *bettingCase {
+
+	bbs := []int64{2, 10, 20, 100}
+	bb := bbs[r.Intn(len(bbs))]
+
+	c := &bettingCase{
+		Limit:   bettingLimits[r.Intn(len(bettingLimits))],
+		SB:      bb / 2,
+		BB:      bb,
+		Stacks:  make([]int64, 2+r.Intn(9)),
+		Choices: make([]int, r.Intn(200)),
+	}
+
+	if r.Intn(2) == 0 {
+		c.Ante = bb / int64(1+r.Intn(4))
+	}
+
+	for i := range c.Stacks {
+
+		// Short stacks are not able to pay blinds
+		if r.Intn(5) == 0 {
+			c.Stacks[i] = 1 + r.Int63n(bb*2)
+			continue
+		}
+
+		c.Stacks[i] = bb + r.Int63n(bb*200)
+	}
+
+	for i := range c.Choices {
+		c.Choices[i] = r.Intn(1 << 16)
+	}
+
+	return c
+}
+
+func (c *bettingCase) clone() *bettingCase {
+
+	cc := *c
+	cc.Stacks = append(make([]int64, 0, len(c.Stacks)), c.Stacks...)
+	cc.Choices = append(make([]int, 0, len(c.Choices)), c.Choices...)
+
+	return &cc
+}
+
+func (c *bettingCase) positions(idx int) []string {
+
+	if len(c.Stacks) == 2 {
+		return [][]string{{"dealer", "sb"}, {"bb"}}[idx]
+	}
+
+	switch idx {
+	case 0:
+		return []string{"dealer"}
+	case 1:
+		return []string{"sb"}
+	case 2:
+		return []string{"bb"}
+	}
+
+	return []string{}
+}
+
+func (c *bettingCase) options() *pokerface.GameOptions {
+
+	opts := pokerface.NewStardardGameOptions()
+	opts.Limit = c.Limit
+	opts.Ante = c.Ante
+	opts.Blind.SB = c.SB
+	opts.Blind.BB = c.BB
+	opts.Deck = pokerface.NewStandardDeckCards()
+	opts.StackedDeck = true
+
+	for i, s := range c.Stacks {
+		opts.Players = append(opts.Players, &pokerface.PlayerSetting{
+			Bankroll:  s,
+			Positions: c.positions(i),
+		})
+	}
+
+	return opts
+}
+
+// scenario renders case with steps which were made, the deck is dealt in
+// order as scenario does by default.
+func (c *bettingCase) scenario(steps []string) string {
+
+	lines := []string{
+		"name Shrunk betting case",
+		"limit " + c.Limit,
+		fmt.Sprintf("ante %d", c.Ante),
+		fmt.Sprintf("blinds %d %d", c.SB, c.BB),
+	}
+
+	for i, s := range c.Stacks {
+		lines = append(lines, strings.TrimSpace(fmt.Sprintf("seat %d %s", s, strings.Join(c.positions(i), " "))))
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, steps...)
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// choose picks an action and chips by choice, passive action is picked once
+// choices run out.
+func choose(gs *pokerface.GameState, ps *pokerface.PlayerState, actions []string, choice int) (string, int64) {
+
+	if choice < 0 {
+		for _, passive := range []string{"check", "call", "fold", "pass", "allin"} {
+			for _, a := range actions {
+				if a == passive {
+					return a, 0
+				}
+			}
+		}
+
+		return "", 0
+	}
+
+	action := actions[choice%len(actions)]
+	n := int64(choice / len(actions))
+
+	// Player who is not able to afford minimum goes all-in
+	pick := func(min int64, max int64) int64 {
+		if max <= min {
+			return max
+		}
+
+		return min + n%(max-min+1)
+	}
+
+	switch action {
+	case "bet":
+		return action, pick(gs.Status.MiniBet, ps.StackSize)
+	case "raise":
+		return action, pick(gs.Status.CurrentWager+gs.Status.PreviousRaiseSize, ps.InitialStackSize)
+	}
+
+	return action, 0
+}
+
+// chooseIllegal picks an action which must be rejected by choice, it returns
+// empty action if there is nothing to try.
+func chooseIllegal(gs *pokerface.GameState, ps *pokerface.PlayerState, actions []string, choice int) (string, int64) {
+
+	allowed := make(map[string]bool)
+	for _, a := range actions {
+		allowed[a] = true
+	}
+
+	type attempt struct {
+		action string
+		chips  int64
+	}
+
+	n := int64(choice)
+	attempts := make([]attempt, 0)
+
+	// Actions which are not allowed at all
+	for _, a := range []string{"check", "call", "bet", "raise"} {
+		if !allowed[a] {
+			attempts = append(attempts, attempt{a, gs.Status.CurrentWager + gs.Meta.Blind.BB})
+		}
+	}
+
+	// Out of range amounts
+	if allowed["bet"] {
+		attempts = append(attempts,
+			attempt{"bet", 0},
+			attempt{"bet", ps.StackSize + 1 + n%gs.Meta.Blind.BB},
+		)
+	}
+
+	if allowed["raise"] {
+		attempts = append(attempts,
+			attempt{"raise", ps.InitialStackSize + 1 + n%gs.Meta.Blind.BB},
+		)
+
+		if gs.Status.CurrentWager > 1 {
+			attempts = append(attempts, attempt{"raise", 1 + n%(gs.Status.CurrentWager-1)})
+		}
+
+		// Less than minimum raise but more than call
+		min := gs.Status.CurrentWager + gs.Status.PreviousRaiseSize
+		if min <= ps.InitialStackSize && min-1 > gs.Status.CurrentWager {
+			attempts = append(attempts, attempt{"raise", gs.Status.CurrentWager + 1 + n%(min-1-gs.Status.CurrentWager)})
+		}
+	}
+
+	if len(attempts) == 0 {
+		return "", 0
+	}
+
+	a := attempts[choice%len(attempts)]
+
+	return a.action, a.chips
+}
+
+// playBettingAction takes action for player, it returns description of step
+func playBettingAction(p pokerface.Player, action string, chips int64) (string, error) {
+
+	desc := fmt.Sprintf("p%d %s", p.SeatIndex(), action)
+	switch action {
+	case "pass":
+		return desc, p.Pass()
+	case "fold":
+		return desc, p.Fold()
+	case "check":
+		return desc, p.Check()
+	case "call":
+		return desc, p.Call()
+	case "allin":
+		return desc, p.Allin()
+	case "bet":
+		return fmt.Sprintf("%s %d", desc, chips), p.Bet(chips)
+	case "raise":
+		return fmt.Sprintf("%s %d", desc, chips), p.Raise(chips)
+	}
+
+	return desc, fmt.Errorf("unknown action %s", action)
+}
+
+func runBettingCase(c *bettingCase, property func(gs *pokerface.GameState) error) (run *bettingRun) {
+
+	run = &bettingRun{
+		Steps: make([]string, 0),
+	}
+
+	step := 0
+	fail := func(kind string, format string, args ...interface{}) *bettingRun {
+		run.Failure = &bettingFailure{
+			Kind:    kind,
+			Step:    step,
+			Message: fmt.Sprintf(format, args...),
+		}
+		return run
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			fail("panic", "%v", r)
+		}
+	}()
+
+	var total int64
+	for _, s := range c.Stacks {
+		total += s
+	}
+
+	g := pokerface.NewPokerFace().NewGame(c.options())
+	if err := g.Start(); err != nil {
+		return fail("error", "start: %v", err)
+	}
+
+	choice := 0
+	for ; g.GetState().Status.CurrentEvent != "GameClosed"; step++ {
+
+		if step >= bettingMaxSteps {
+			return fail("progress", "game is not closed after %d steps", step)
+		}
+
+		gs := g.GetState()
+		before := progressKey(gs)
+
+		var err error
+		var desc string
+		switch gs.Status.CurrentEvent {
+		case "ReadyRequested":
+			desc = "ready"
+			err = g.ReadyForAll()
+		case "AnteRequested":
+			desc = "pay_ante"
+			err = g.PayAnte()
+		case "BlindsRequested":
+			desc = "pay_blinds"
+			err = g.PayBlinds()
+		case "RoundClosed":
+			desc = "next"
+			err = g.Next()
+		case "RoundStarted":
+
+			p := g.GetCurrentPlayer()
+			actions := g.GetAvailableActions(p)
+			if len(actions) == 0 {
+				return fail("progress", "p%d has no available action", p.SeatIndex())
+			}
+
+			ch := -1
+			if choice < len(c.Choices) {
+				ch = c.Choices[choice]
+				choice++
+			}
+
+			// Every fourth choice tries an illegal action first
+			if ch >= 0 && ch%4 == 0 {
+
+				action, chips := chooseIllegal(gs, p.State(), actions, ch/4)
+				if len(action) > 0 {
+
+					desc, err := playBettingAction(p, action, chips)
+					run.Steps = append(run.Steps, desc, "expect error")
+
+					if err == nil {
+						return fail("illegal", "%s was accepted", desc)
+					}
+
+					if progressKey(g.GetState()) != before {
+						return fail("illegal", "%s changed state: %v", desc, err)
+					}
+				}
+			}
+
+			action, chips := choose(gs, p.State(), actions, ch)
+			desc, err = playBettingAction(p, action, chips)
+
+		default:
+			return fail("progress", "stuck at %s", gs.Status.CurrentEvent)
+		}
+
+		run.Steps = append(run.Steps, desc)
+
+		if err != nil {
+			return fail("error", "%s: %v", desc, err)
+		}
+
+		gs = g.GetState()
+		if progressKey(gs) == before {
+			return fail("progress", "%s changed nothing", desc)
+		}
+
+		if violations := pokerface.Audit(gs); len(violations) > 0 {
+			return fail("invariant", "%s: %s", desc, violations[0].String())
+		}
+
+		var chips int64
+		for _, ps := range gs.Players {
+			chips += ps.StackSize + ps.Wager + ps.Pot
+		}
+
+		if chips != total {
+			return fail("invariant", "%s: %d chips on table, %d expected", desc, chips, total)
+		}
+
+		if property != nil {
+			if err := property(gs); err != nil {
+				return fail("property", "%s: %v", desc, err)
+			}
+		}
+	}
+
+	// Chips are not created or lost by settlement
+	var final int64
+	for _, pr := range g.GetState().Result.Players {
+		final += pr.Final
+	}
+
+	if final != total {
+		return fail("invariant", "%d chips after settlement, %d expected", final, total)
+	}
+
+	return run
+}
+
+// progressKey ignores timestamp which is updated by every event
+func progressKey(gs *pokerface.GameState) string {
+
+	state := *gs
+	state.UpdatedAt = 0
+
+	data, _ := json.Marshal(&state)
+
+	return string(data)
+}
+
+// shrinkBettingCase looks for a smaller case which fails in the same way
+func shrinkBettingCase(c *bettingCase, property func(gs *pokerface.GameState) error) *bettingCase {
+
+	kind := runBettingCase(c, property).Failure.Kind
+	fails := func(cc *bettingCase) bool {
+		f := runBettingCase(cc, property).Failure
+		return f != nil && f.Kind == kind
+	}
+
+	for budget := 2000; budget > 0; {
+
+		improved := false
+		for _, cc := range bettingCandidates(c) {
+
+			budget--
+			if fails(cc) {
+				c = cc
+				improved = true
+				break
+			}
+		}
+
+		if !improved {
+			break
+		}
+	}
+
+	return c
+}
+
+func bettingCandidates(c *bettingCase) []*bettingCase {
+
+	candidates := make([]*bettingCase, 0)
+	add := func(fn func(cc *bettingCase)) {
+		cc := c.clone()
+		fn(cc)
+		candidates = append(candidates, cc)
+	}
+
+	// Less players
+	for i := range c.Stacks {
+		if len(c.Stacks) > 2 {
+			i := i
+			add(func(cc *bettingCase) {
+				cc.Stacks = append(cc.Stacks[:i], cc.Stacks[i+1:]...)
+			})
+		}
+	}
+
+	// Less choices
+	if n := len(c.Choices); n > 0 {
+		add(func(cc *bettingCase) { cc.Choices = cc.Choices[:n/2] })
+		add(func(cc *bettingCase) { cc.Choices = cc.Choices[:n-1] })
+	}
+
+	for i := range c.Choices {
+		i := i
+		add(func(cc *bettingCase) {
+			cc.Choices = append(cc.Choices[:i], cc.Choices[i+1:]...)
+		})
+	}
+
+	// Simpler settings
+	if c.Ante > 0 {
+		add(func(cc *bettingCase) { cc.Ante = 0 })
+	}
+
+	if c.Limit != "no" {
+		add(func(cc *bettingCase) { cc.Limit = "no" })
+	}
+
+	// Smaller numbers
+	for i, ch := range c.Choices {
+		if ch > 0 {
+			i := i
+			add(func(cc *bettingCase) { cc.Choices[i] /= 2 })
+		}
+	}
+
+	for i, s := range c.Stacks {
+		if s > 1 {
+			i := i
+			add(func(cc *bettingCase) { cc.Stacks[i] /= 2 })
+		}
+	}
+
+	return candidates
+}
+
+func checkBettingCase(t *testing.T, c *bettingCase) {
+
+	if run := runBettingCase(c, nil); run.Failure != nil {
+
+		c = shrinkBettingCase(c, nil)
+		run = runBettingCase(c, nil)
+
+		t.Fatalf("%s\n\nminimal reproduction:\n%s", run.Failure.String(), c.scenario(run.Steps))
+	}
+}
+
+func TestBetting_RandomCases(t *testing.T) {
+
+	for seed := int64(1); seed <= 300; seed++ {
+		checkBettingCase(t, newBettingCase(rand.New(rand.NewSource(seed))))
+	}
+}
+
+func FuzzBetting(f *testing.F) {
+
+	f.Add(int64(1))
+	f.Add(int64(42))
+
+	f.Fuzz(func(t *testing.T, seed int64) {
+		checkBettingCase(t, newBettingCase(rand.New(rand.NewSource(seed))))
+	})
+}
+
+func TestBetting_Shrink(t *testing.T) {
+
+	// Pretend that a raise over 3 big blinds is a bug
+	property := func(gs *pokerface.GameState) error {
+		if gs.Status.CurrentWager > gs.Meta.Blind.BB*3 {
+			return fmt.Errorf("wager %d is too high", gs.Status.CurrentWager)
+		}
+		return nil
+	}
+
+	var c *bettingCase
+	for seed := int64(1); c == nil; seed++ {
+		cc := newBettingCase(rand.New(rand.NewSource(seed)))
+		if runBettingCase(cc, property).Failure != nil && len(cc.Stacks) > 2 {
+			c = cc
+		}
+	}
+
+	shrunk := shrinkBettingCase(c, property)
+	run := runBettingCase(shrunk, property)
+
+	assert.NotNil(t, run.Failure)
+	assert.Equal(t, "property", run.Failure.Kind)
+	assert.Equal(t, 2, len(shrunk.Stacks))
+	assert.Equal(t, int64(0), shrunk.Ante)
+	assert.LessOrEqual(t, len(shrunk.Choices), 2)
+
+	// Reproduction is a valid scenario
+	s, err := scenario.Parse("shrunk.scenario", strings.NewReader(shrunk.scenario(run.Steps)))
+	assert.Nil(t, err)
+
+	// Replaying ends up with the same failure
+	r := scenario.RunGame(s)
+	assert.True(t, r.Passed(), r.String())
+	assert.NotNil(t, property(r.State))
+}
@@ -0,0 +1,86 @@
+package pokerface
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokerface"
+	"github.com/weedbox/pokerface/card"
+)
+
+func Test_Cards_JSONCompatibility(t *testing.T) {
+
+	pf := pokerface.NewPokerFace()
+
+	opts := pokerface.NewStardardGameOptions()
+	opts.Deck = pokerface.NewStandardDeckCards()
+	opts.Players = append(opts.Players,
+		&pokerface.PlayerSetting{
+			Bankroll:  10000,
+			Positions: []string{"dealer", "sb"},
+		},
+		&pokerface.PlayerSetting{
+			Bankroll:  10000,
+			Positions: []string{"bb"},
+		},
+	)
+
+	g := pf.NewGame(opts)
+	assert.Nil(t, g.Start())
+	assert.Nil(t, g.ReadyForAll())
+	assert.Equal(t, "BlindsRequested", g.GetState().Status.CurrentEvent)
+
+	data, err := g.GetStateJSON()
+	assert.Nil(t, err)
+
+	// Cards are still in string notation
+	var raw struct {
+		Meta struct {
+			Deck []string `json:"deck"`
+		} `json:"meta"`
+		Players []struct {
+			HoleCards   []string `json:"hole_cards"`
+			Combination struct {
+				Cards []string `json:"cards"`
+			} `json:"combination"`
+		} `json:"players"`
+	}
+	assert.Nil(t, json.Unmarshal(data, &raw))
+
+	// Deck is never in state
+	assert.Nil(t, raw.Meta.Deck)
+
+	for i, p := range raw.Players {
+		assert.Equal(t, card.Strings(g.GetState().Players[i].HoleCards), p.HoleCards)
+		assert.Equal(t, 2, len(p.Combination.Cards))
+	}
+
+	// Restore from JSON
+	var gs pokerface.GameState
+	assert.Nil(t, json.Unmarshal(data, &gs))
+	for i, p := range gs.Players {
+		assert.Equal(t, g.GetState().Players[i].HoleCards, p.HoleCards)
+	}
+}
+
+func Test_Cards_InvalidDeck(t *testing.T) {
+
+	pf := pokerface.NewPokerFace()
+
+	opts := pokerface.NewStardardGameOptions()
+	opts.Deck = card.MustParseCards("S2", "S3", "S2", "S4", "S5", "S6")
+	opts.Players = append(opts.Players,
+		&pokerface.PlayerSetting{
+			Bankroll:  10000,
+			Positions: []string{"dealer", "sb"},
+		},
+		&pokerface.PlayerSetting{
+			Bankroll:  10000,
+			Positions: []string{"bb"},
+		},
+	)
+
+	g := pf.NewGame(opts)
+	assert.Equal(t, pokerface.ErrInvalidDeck, g.Start())
+}
@@ -0,0 +1,214 @@
+package pokerface
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokerface"
+)
+
+// playRandomGame plays a game with random actions and returns states of
+// every step.
+func playRandomGame(r *rand.Rand, playerCount int) []*pokerface.GameState {
+
+	pf := pokerface.NewPokerFace()
+
+	opts := pokerface.NewStardardGameOptions()
+	opts.Ante = int64(r.Intn(3)) * 5
+	opts.Deck = pokerface.NewStandardDeckCards()
+	for i := 0; i < playerCount; i++ {
+		opts.Players = append(opts.Players, &pokerface.PlayerSetting{
+			Bankroll:  int64(100 + r.Intn(1000)),
+			Positions: []string{},
+		})
+	}
+	opts.Players[0].Positions = []string{"dealer"}
+	opts.Players[1%playerCount].Positions = append(opts.Players[1%playerCount].Positions, "sb")
+	opts.Players[2%playerCount].Positions = append(opts.Players[2%playerCount].Positions, "bb")
+
+	g := pf.NewGame(opts)
+	if err := g.Start(); err != nil {
+		return nil
+	}
+
+	states := []*pokerface.GameState{g.GetState()}
+	snapshot := func() {
+		data, _ := g.GetStateJSON()
+		var gs pokerface.GameState
+		json.Unmarshal(data, &gs)
+		states = append(states, &gs)
+	}
+
+	for i := 0; i < 200 && g.GetState().Status.CurrentEvent != "GameClosed"; i++ {
+
+		var err error
+		switch g.GetState().Status.CurrentEvent {
+		case "ReadyRequested":
+			err = g.ReadyForAll()
+		case "AnteRequested":
+			err = g.PayAnte()
+		case "BlindsRequested":
+			err = g.PayBlinds()
+		case "RoundClosed":
+			err = g.Next()
+		case "RoundStarted":
+
+			p := g.GetCurrentPlayer()
+			actions := p.State().AllowedActions
+			switch actions[r.Intn(len(actions))] {
+			case "fold":
+				err = p.Fold()
+			case "check":
+				err = p.Check()
+			case "call":
+				err = p.Call()
+			case "allin":
+				err = p.Allin()
+			case "bet":
+				err = p.Bet(g.GetState().Meta.Blind.BB * int64(1+r.Intn(3)))
+			case "raise":
+				gs := g.GetState()
+				err = p.Raise(gs.Status.CurrentWager + gs.Status.PreviousRaiseSize + int64(r.Intn(3))*gs.Meta.Blind.BB)
+			}
+
+			// Fallback for invalid size
+			if err != nil {
+				if p.CheckAction("check") {
+					err = p.Check()
+				} else {
+					err = p.Fold()
+				}
+			}
+		default:
+			return states
+		}
+
+		if err != nil {
+			return states
+		}
+
+		snapshot()
+	}
+
+	return states
+}
+
+func assertCodecLossless(t *testing.T, gs *pokerface.GameState) {
+
+	expected, err := json.Marshal(gs)
+	assert.Nil(t, err)
+
+	data, err := pokerface.MarshalGameState(gs)
+	assert.Nil(t, err)
+	assert.Less(t, len(data), len(expected))
+
+	decoded, err := pokerface.UnmarshalGameState(data)
+	assert.Nil(t, err)
+
+	actual, err := json.Marshal(decoded)
+	assert.Nil(t, err)
+	assert.JSONEq(t, string(expected), string(actual))
+}
+
+func TestCodec_GameState(t *testing.T) {
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		for _, gs := range playRandomGame(r, 2+i%8) {
+			assertCodecLossless(t, gs)
+		}
+	}
+}
+
+func FuzzCodec_GameState(f *testing.F) {
+
+	f.Add(int64(1), uint8(3))
+	f.Add(int64(2), uint8(9))
+
+	f.Fuzz(func(t *testing.T, seed int64, players uint8) {
+
+		r := rand.New(rand.NewSource(seed))
+		for _, gs := range playRandomGame(r, 2+int(players)%8) {
+			assertCodecLossless(t, gs)
+		}
+	})
+}
+
+func FuzzCodec_UnmarshalGameState(f *testing.F) {
+
+	r := rand.New(rand.NewSource(1))
+	for _, gs := range playRandomGame(r, 3) {
+		data, _ := pokerface.MarshalGameState(gs)
+		f.Add(data)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+
+		gs, err := pokerface.UnmarshalGameState(data)
+		if err != nil {
+			return
+		}
+
+		// Decoded state must be encoded again with the same JSON
+		encoded, err := pokerface.MarshalGameState(gs)
+		assert.Nil(t, err)
+
+		decoded, err := pokerface.UnmarshalGameState(encoded)
+		assert.Nil(t, err)
+
+		expected, _ := json.Marshal(gs)
+		actual, _ := json.Marshal(decoded)
+		assert.Equal(t, string(expected), string(actual))
+	})
+}
+
+func benchmarkGameState() *pokerface.GameState {
+
+	r := rand.New(rand.NewSource(1))
+	states := playRandomGame(r, 9)
+
+	return states[len(states)-1]
+}
+
+func BenchmarkCodec_MarshalGameState_JSON(b *testing.B) {
+
+	gs := benchmarkGameState()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		json.Marshal(gs)
+	}
+}
+
+func BenchmarkCodec_MarshalGameState_Binary(b *testing.B) {
+
+	gs := benchmarkGameState()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pokerface.MarshalGameState(gs)
+	}
+}
+
+func BenchmarkCodec_UnmarshalGameState_JSON(b *testing.B) {
+
+	data, _ := json.Marshal(benchmarkGameState())
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var gs pokerface.GameState
+		json.Unmarshal(data, &gs)
+	}
+}
+
+func BenchmarkCodec_UnmarshalGameState_Binary(b *testing.B) {
+
+	data, _ := pokerface.MarshalGameState(benchmarkGameState())
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pokerface.UnmarshalGameState(data)
+	}
+}
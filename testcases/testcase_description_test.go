@@ -0,0 +1,81 @@
+package pokerface
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokerface"
+)
+
+func TestDescription_Results(t *testing.T) {
+
+	kickers := 0
+	for i := 0; i < 100; i++ {
+
+		pf := pokerface.NewPokerFace()
+
+		opts := pokerface.NewStardardGameOptions()
+		opts.Deck = pokerface.NewStandardDeckCards()
+		for j := 0; j < 6; j++ {
+			opts.Players = append(opts.Players, &pokerface.PlayerSetting{
+				Bankroll: 10000,
+			})
+		}
+		opts.Players[0].Positions = []string{"dealer"}
+		opts.Players[1].Positions = []string{"sb"}
+		opts.Players[2].Positions = []string{"bb"}
+
+		g := pf.NewGame(opts)
+		assert.Nil(t, g.Start())
+
+		runCheckDown(t, g)
+
+		gs := g.GetState()
+		for _, p := range gs.Players {
+			assert.NotEmpty(t, p.Combination.Description)
+			assert.Equal(t, p.Combination.Description, gs.Result.Players[p.Idx].Description)
+		}
+
+		for _, pot := range gs.Result.Pots {
+			for _, w := range pot.Winners {
+
+				if len(w.Kicker) == 0 {
+					continue
+				}
+
+				kickers++
+				assert.True(t, strings.HasSuffix(w.Kicker, " kicker"))
+
+				// Kicker must be one of winner's kickers
+				desc := gs.Players[w.Idx].Combination.Description
+				assert.Contains(t, desc, strings.TrimSuffix(w.Kicker, " kicker"))
+			}
+		}
+	}
+
+	// Kickers decide some games for sure with six players
+	assert.Greater(t, kickers, 0)
+}
+
+func TestDescription_UnknownLocale(t *testing.T) {
+
+	pf := pokerface.NewPokerFace()
+
+	opts := pokerface.NewStardardGameOptions()
+	opts.Locale = "unknown"
+	opts.Deck = pokerface.NewStandardDeckCards()
+	opts.Players = append(opts.Players,
+		&pokerface.PlayerSetting{
+			Bankroll:  10000,
+			Positions: []string{"dealer", "sb"},
+		},
+		&pokerface.PlayerSetting{
+			Bankroll:  10000,
+			Positions: []string{"bb"},
+		},
+	)
+
+	g := pf.NewGame(opts)
+	assert.Equal(t, pokerface.ErrUnknownLocale, g.Start())
+}
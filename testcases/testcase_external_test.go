@@ -0,0 +1,144 @@
+package pokerface
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokerface"
+	"github.com/weedbox/pokerface/card"
+)
+
+var externalTestEngine = pokerface.NewPokerFace()
+
+func newExternalTestGame(t *testing.T) pokerface.Game {
+
+	opts := newSecretsTestOptions()
+	opts.CardSource = pokerface.CardSourceExternal
+	opts.Deck = nil
+
+	g := externalTestEngine.NewGame(opts)
+	assert.Nil(t, g.Start())
+	assert.Nil(t, g.ReadyForAll())
+
+	return g
+}
+
+func TestExternal_PushCards(t *testing.T) {
+
+	g := newExternalTestGame(t)
+	gs := g.GetState()
+
+	assert.Equal(t, "CardsRequested", gs.Status.CurrentEvent)
+	assert.Equal(t, "preflop", gs.Status.CardsRequest.Round)
+	assert.Equal(t, 6, gs.Status.CardsRequest.Count)
+
+	holeCards := card.MustParseCards("SA", "SK", "HA", "HK", "DA", "DK")
+
+	// Count and duplicates are validated
+	assert.Equal(t, pokerface.ErrTooManyCards, g.PushCards(card.MustParseCards("SA", "SK", "HA", "HK", "DA", "DK", "CA")...))
+	assert.Equal(t, pokerface.ErrDuplicateCard, g.PushCards(card.MustParseCards("SA", "SA")...))
+	assert.Equal(t, card.ErrInvalidCard, g.PushCards(card.Card(0xff)))
+
+	assert.Nil(t, g.PushCards(holeCards[:5]...))
+	assert.Equal(t, pokerface.ErrDuplicateCard, g.PushCards(holeCards[0]))
+
+	// Pushed cards are never in state
+	assert.Equal(t, 0, len(g.GetState().Status.CardsRequest.Cards))
+	assert.Equal(t, holeCards[:5], g.GetSecrets().Pushed)
+
+	// Game is restored from state while waiting for cards
+	g, err := externalTestEngine.LoadGame(g.GetState().Clone())
+	assert.Nil(t, err)
+	assert.Equal(t, "CardsRequested", g.GetState().Status.CurrentEvent)
+	assert.Nil(t, g.PushCards(holeCards[5]))
+
+	gs = g.GetState()
+	assert.Equal(t, "BlindsRequested", gs.Status.CurrentEvent)
+	assert.Equal(t, &pokerface.CardsRequest{Round: "preflop", Count: 6}, gs.Status.CardsRequest)
+	for i, p := range gs.Players {
+		assert.Equal(t, holeCards[i*2:i*2+2], p.HoleCards)
+	}
+
+	assert.Equal(t, pokerface.ErrCardsNotRequested, g.PushCards(card.MustParseCards("CA")...))
+
+	// Misread is correctable before round starts
+	assert.Equal(t, pokerface.ErrDuplicateCard, g.CorrectCard(card.MustParse("SA"), card.MustParse("HA")))
+	assert.Equal(t, pokerface.ErrNotFoundCard, g.CorrectCard(card.MustParse("CA"), card.MustParse("CK")))
+	assert.Nil(t, g.CorrectCard(card.MustParse("SK"), card.MustParse("CA")))
+	assert.Equal(t, card.MustParseCards("SA", "CA"), g.GetState().Players[0].HoleCards)
+	assert.Equal(t, card.MustParseCards("SA", "CA"), g.GetState().Players[0].Combination.Cards)
+
+	assert.Nil(t, g.PayBlinds())
+	assert.Nil(t, g.ReadyForAll())
+	assert.Equal(t, "RoundStarted", g.GetState().Status.CurrentEvent)
+	assert.Equal(t, pokerface.ErrRoundStarted, g.CorrectCard(card.MustParse("SA"), card.MustParse("CQ")))
+}
+
+func TestExternal_Game(t *testing.T) {
+
+	g := newExternalTestGame(t)
+
+	cards := card.MustParseCards(
+		"SA", "SK", "HA", "HK", "DA", "DK",
+		"C2", "H3", "D9",
+		"C7",
+		"H8",
+	)
+
+	pushed := 0
+	for g.GetState().Status.CurrentEvent != "GameClosed" {
+
+		switch g.GetState().Status.CurrentEvent {
+		case "CardsRequested":
+			count := g.GetState().Status.CardsRequest.Count
+			assert.Nil(t, g.PushCards(cards[pushed:pushed+count]...))
+			pushed += count
+		case "ReadyRequested":
+			assert.Nil(t, g.ReadyForAll())
+		case "BlindsRequested":
+			assert.Nil(t, g.PayBlinds())
+		case "RoundClosed":
+			assert.Nil(t, g.Next())
+		case "RoundStarted":
+			if g.GetCurrentPlayer().CheckAction("check") {
+				assert.Nil(t, g.Check())
+			} else {
+				assert.Nil(t, g.Call())
+			}
+		default:
+			t.Fatalf("unexpected event: %s", g.GetState().Status.CurrentEvent)
+		}
+	}
+
+	gs := g.GetState()
+	assert.Equal(t, len(cards), pushed)
+	assert.Equal(t, cards[6:], gs.Status.Board)
+	assert.Equal(t, 0, len(g.GetSecrets().Burned))
+
+	assert.Equal(t, "High Card, Ace, King-Nine-Eight-Seven kickers", gs.Players[0].Combination.Description)
+}
+
+func TestExternal_Redact(t *testing.T) {
+
+	g := newExternalTestGame(t)
+	assert.Nil(t, g.PushCards(card.MustParseCards("SA", "SK", "HA", "HK", "DA", "DK")...))
+
+	// Cards request of older state might have cards
+	gs := g.GetState().Clone()
+	gs.Status.CardsRequest.Cards = card.MustParseCards("SA", "SK", "HA", "HK", "DA", "DK")
+
+	state := gs.Redact(0, pokerface.NewRedactionPolicy())
+	assert.Equal(t, 0, len(state.Status.CardsRequest.Cards))
+	assert.Equal(t, card.MustParseCards("SA", "SK"), state.Players[0].HoleCards)
+
+	// Nothing of opponents is visible
+	visible := card.NewCardSet(state.Status.Board...)
+	for _, p := range state.Players {
+		visible = visible.Add(p.HoleCards...)
+	}
+
+	assert.Equal(t, 2, visible.Count())
+
+	state = gs.Redact(pokerface.ViewerObserver, pokerface.NewStaffRedactionPolicy())
+	assert.Equal(t, 6, len(state.Status.CardsRequest.Cards))
+}
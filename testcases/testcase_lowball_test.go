@@ -0,0 +1,118 @@
+package pokerface
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokerface"
+	"github.com/weedbox/pokerface/card"
+	"github.com/weedbox/pokerface/combination"
+)
+
+func runCheckDown(t *testing.T, g pokerface.Game) {
+
+	for g.GetState().Status.CurrentEvent != "GameClosed" {
+
+		switch g.GetState().Status.CurrentEvent {
+		case "ReadyRequested":
+			assert.Nil(t, g.ReadyForAll())
+		case "AnteRequested":
+			assert.Nil(t, g.PayAnte())
+		case "BlindsRequested":
+			assert.Nil(t, g.PayBlinds())
+		case "RoundClosed":
+			assert.Nil(t, g.Next())
+		case "RoundStarted":
+			if g.GetCurrentPlayer().CheckAction("check") {
+				assert.Nil(t, g.Check())
+			} else {
+				assert.Nil(t, g.Call())
+			}
+		default:
+			t.Fatalf("unexpected event: %s", g.GetState().Status.CurrentEvent)
+		}
+	}
+}
+
+func assertLowballResults(t *testing.T, opts *pokerface.GameOptions, e combination.Evaluator) {
+
+	pf := pokerface.NewPokerFace()
+
+	opts.Deck = pokerface.NewStandardDeckCards()
+	opts.Players = append(opts.Players,
+		&pokerface.PlayerSetting{
+			Bankroll:  10000,
+			Positions: []string{"dealer"},
+		},
+		&pokerface.PlayerSetting{
+			Bankroll:  10000,
+			Positions: []string{"sb"},
+		},
+		&pokerface.PlayerSetting{
+			Bankroll:  10000,
+			Positions: []string{"bb"},
+		},
+	)
+
+	g := pf.NewGame(opts)
+	assert.Nil(t, g.Start())
+
+	runCheckDown(t, g)
+
+	gs := g.GetState()
+	assert.Equal(t, 5, len(gs.Status.Board))
+
+	// Power of players should be calculated by lowball evaluator
+	best := 0
+	for _, p := range gs.Players {
+
+		cards := make([]card.Card, 0)
+		cards = append(cards, p.HoleCards...)
+		cards = append(cards, gs.Status.Board...)
+
+		assert.Equal(t, int(e.Evaluate(cards)), p.Combination.Power)
+
+		if p.Combination.Power > best {
+			best = p.Combination.Power
+		}
+	}
+
+	// The lowest hand takes the pot
+	for _, rs := range gs.Result.Players {
+		if gs.Players[rs.Idx].Combination.Power == best {
+			assert.GreaterOrEqual(t, rs.Changed, int64(0))
+		} else {
+			assert.Equal(t, int64(-10), rs.Changed)
+		}
+	}
+}
+
+func Test_Lowball_A5(t *testing.T) {
+	assertLowballResults(t, pokerface.NewLowballA5GameOptions(), combination.NewLowballA5Evaluator(nil))
+}
+
+func Test_Lowball_27(t *testing.T) {
+	assertLowballResults(t, pokerface.NewLowball27GameOptions(), combination.NewLowball27Evaluator(nil))
+}
+
+func Test_Lowball_UnknownEvaluator(t *testing.T) {
+
+	pf := pokerface.NewPokerFace()
+
+	opts := pokerface.NewStardardGameOptions()
+	opts.Evaluator = "unknown"
+	opts.Deck = pokerface.NewStandardDeckCards()
+	opts.Players = append(opts.Players,
+		&pokerface.PlayerSetting{
+			Bankroll:  10000,
+			Positions: []string{"dealer", "sb"},
+		},
+		&pokerface.PlayerSetting{
+			Bankroll:  10000,
+			Positions: []string{"bb"},
+		},
+	)
+
+	g := pf.NewGame(opts)
+	assert.Equal(t, pokerface.ErrUnknownEvaluator, g.Start())
+}
@@ -0,0 +1,78 @@
+package pokerface
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokerface"
+	"github.com/weedbox/pokerface/card"
+	"github.com/weedbox/pokerface/mentalpoker"
+)
+
+func TestMentalPoker_Game(t *testing.T) {
+
+	opts := newSecretsTestOptions()
+
+	// Every player is a party of dealing
+	group := mentalpoker.DefaultGroup()
+	parties := make([]mentalpoker.Party, len(opts.Players))
+	for i := range parties {
+		p, err := mentalpoker.NewLocalParty(group)
+		assert.Nil(t, err)
+		parties[i] = p
+	}
+
+	session := mentalpoker.NewSession(group, opts.Deck, parties...)
+
+	pf := pokerface.NewPokerFace(pokerface.WithSecretStore(pokerface.NewMemorySecretStore()))
+	g := pf.NewGame(opts)
+	g.SetDealing(session)
+	assert.Nil(t, g.Start())
+	assert.Equal(t, pokerface.CardSourceDealing, g.GetState().Meta.CardSource)
+
+	// Restored game cannot deal without session
+	restored, err := pf.LoadGame(g.GetState().Clone())
+	assert.Nil(t, err)
+	assert.Equal(t, pokerface.ErrDealingNotSet, restored.ReadyForAll())
+
+	dealt, err := restored.Deal(1)
+	assert.Equal(t, pokerface.ErrDealingNotSet, err)
+	assert.Nil(t, dealt)
+
+	runCheckDown(t, g)
+
+	gs := g.GetState()
+	assert.Equal(t, 5, len(gs.Status.Board))
+
+	cards := append(make([]card.Card, 0), gs.Status.Board...)
+	for _, p := range gs.Players {
+		assert.Equal(t, 2, len(p.HoleCards))
+		cards = append(cards, p.HoleCards...)
+	}
+
+	// Cards are unique and burned cards are never revealed
+	assert.Equal(t, 11, card.NewCardSet(cards...).Count())
+	assert.Equal(t, 52-11-3, session.Remaining())
+	assert.Equal(t, 0, len(g.GetSecrets().Deck))
+	assert.Equal(t, 0, len(g.GetSecrets().Burned))
+	assert.Equal(t, 14, gs.Status.CurrentDeckPosition)
+}
+
+func TestMentalPoker_NotEnoughCards(t *testing.T) {
+
+	opts := newSecretsTestOptions()
+
+	group := mentalpoker.DefaultGroup()
+	parties := make([]mentalpoker.Party, len(opts.Players))
+	for i := range parties {
+		p, err := mentalpoker.NewLocalParty(group)
+		assert.Nil(t, err)
+		parties[i] = p
+	}
+
+	// Deck of session is too small to deal hole cards
+	g := pokerface.NewPokerFace().NewGame(opts)
+	g.SetDealing(mentalpoker.NewSession(group, opts.Deck[:4], parties...))
+	assert.Nil(t, g.Start())
+	assert.Equal(t, mentalpoker.ErrNotEnoughCards, g.ReadyForAll())
+}
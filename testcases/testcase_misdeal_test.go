@@ -0,0 +1,127 @@
+package pokerface
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokerface"
+)
+
+func newMisdealTestGame(t *testing.T, limit string) pokerface.Game {
+
+	opts := pokerface.NewStardardGameOptions()
+	opts.Ante = 10
+	opts.Deck = pokerface.NewStandardDeckCards()
+	opts.MisdealLimit = limit
+	opts.Players = append(opts.Players,
+		&pokerface.PlayerSetting{
+			Bankroll:  10000,
+			Positions: []string{"dealer"},
+		},
+		&pokerface.PlayerSetting{
+			Bankroll:  10000,
+			Positions: []string{"sb"},
+		},
+		&pokerface.PlayerSetting{
+			Bankroll:  10000,
+			Positions: []string{"bb"},
+		},
+	)
+
+	pf := pokerface.NewPokerFace()
+	g := pf.NewGame(opts)
+	assert.Nil(t, g.Start())
+
+	// Ante and blinds
+	for g.GetState().Status.CurrentEvent != "RoundStarted" {
+		switch g.GetState().Status.CurrentEvent {
+		case "ReadyRequested":
+			assert.Nil(t, g.ReadyForAll())
+		case "AnteRequested":
+			assert.Nil(t, g.PayAnte())
+		case "BlindsRequested":
+			assert.Nil(t, g.PayBlinds())
+		}
+	}
+
+	return g
+}
+
+func TestMisdeal_Refund(t *testing.T) {
+
+	g := newMisdealTestGame(t, pokerface.MisdealBeforeAction)
+
+	assert.Nil(t, g.Misdeal("exposed card"))
+
+	gs := g.GetState()
+	assert.Equal(t, "GameVoided", gs.Status.CurrentEvent)
+	assert.Equal(t, "exposed card", gs.Status.VoidReason)
+	assert.Equal(t, 0, len(gs.Status.Pots))
+
+	for _, p := range gs.Players {
+		assert.Equal(t, int64(10000), p.StackSize)
+		assert.Equal(t, int64(0), p.Pot)
+		assert.Equal(t, int64(0), p.Wager)
+	}
+
+	for _, pr := range gs.Result.Players {
+		assert.Equal(t, int64(10000), pr.Final)
+		assert.Equal(t, int64(0), pr.Changed)
+	}
+
+	// Positions are kept
+	assert.True(t, gs.HasPosition(0, "dealer"))
+	assert.True(t, gs.HasPosition(1, "sb"))
+	assert.True(t, gs.HasPosition(2, "bb"))
+
+	// Game is over
+	assert.Equal(t, pokerface.ErrMisdealNotAllowed, g.Misdeal("again"))
+
+	// Survives codec
+	state, err := pokerface.UnmarshalGameState(mustMarshalGameState(t, gs))
+	assert.Nil(t, err)
+	assert.Equal(t, "exposed card", state.Status.VoidReason)
+	assert.Equal(t, pokerface.MisdealBeforeAction, state.Meta.MisdealLimit)
+}
+
+func TestMisdeal_Limit(t *testing.T) {
+
+	// Voluntary action was made
+	g := newMisdealTestGame(t, pokerface.MisdealBeforeAction)
+	assert.Nil(t, g.Call())
+	assert.Equal(t, pokerface.ErrMisdealNotAllowed, g.Misdeal("exposed card"))
+
+	// Still preflop
+	g = newMisdealTestGame(t, pokerface.MisdealBeforeFlop)
+	assert.Nil(t, g.Call())
+	assert.Nil(t, g.Misdeal("exposed card"))
+
+	// Flop was dealt
+	g = newMisdealTestGame(t, pokerface.MisdealBeforeFlop)
+	assert.Nil(t, g.Call())
+	assert.Nil(t, g.Call())
+	assert.Nil(t, g.Check())
+	assert.Nil(t, g.Next())
+	assert.Equal(t, "flop", g.GetState().Status.Round)
+	assert.Equal(t, pokerface.ErrMisdealNotAllowed, g.Misdeal("exposed card"))
+
+	// No limit
+	g = newMisdealTestGame(t, pokerface.MisdealBeforeShowdown)
+	assert.Nil(t, g.Call())
+	assert.Nil(t, g.Call())
+	assert.Nil(t, g.Check())
+	assert.Nil(t, g.Next())
+	assert.Nil(t, g.Misdeal("exposed card"))
+
+	for _, p := range g.GetState().Players {
+		assert.Equal(t, int64(10000), p.StackSize)
+	}
+}
+
+func mustMarshalGameState(t *testing.T, gs *pokerface.GameState) []byte {
+
+	data, err := pokerface.MarshalGameState(gs)
+	assert.Nil(t, err)
+
+	return data
+}
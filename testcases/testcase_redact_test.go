@@ -0,0 +1,164 @@
+package pokerface
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokerface"
+)
+
+func newRedactGame(t *testing.T) pokerface.Game {
+
+	pf := pokerface.NewPokerFace()
+
+	opts := pokerface.NewStardardGameOptions()
+	opts.Deck = pokerface.NewStandardDeckCards()
+	opts.Players = append(opts.Players,
+		&pokerface.PlayerSetting{
+			Bankroll:  10000,
+			Positions: []string{"dealer"},
+		},
+		&pokerface.PlayerSetting{
+			Bankroll:  10000,
+			Positions: []string{"sb"},
+		},
+		&pokerface.PlayerSetting{
+			Bankroll:  10000,
+			Positions: []string{"bb"},
+		},
+	)
+
+	g := pf.NewGame(opts)
+	assert.Nil(t, g.Start())
+
+	// Preflop
+	for g.GetState().Status.CurrentEvent != "RoundStarted" {
+		switch g.GetState().Status.CurrentEvent {
+		case "ReadyRequested":
+			assert.Nil(t, g.ReadyForAll())
+		case "AnteRequested":
+			assert.Nil(t, g.PayAnte())
+		case "BlindsRequested":
+			assert.Nil(t, g.PayBlinds())
+		default:
+			t.Fatalf("unexpected event: %s", g.GetState().Status.CurrentEvent)
+		}
+	}
+
+	return g
+}
+
+func Test_Redact_NonMutating(t *testing.T) {
+
+	g := newRedactGame(t)
+	gs := g.GetState()
+
+	observer := gs.Redact(pokerface.ViewerObserver, nil)
+	assert.Equal(t, 0, len(observer.Meta.Deck))
+	for _, p := range observer.Players {
+		assert.Equal(t, 0, len(p.HoleCards))
+	}
+
+	player := gs.Redact(1, nil)
+	assert.Equal(t, gs.Players[1].HoleCards, player.Players[1].HoleCards)
+	assert.Equal(t, 0, len(player.Players[0].HoleCards))
+	assert.Equal(t, 0, len(player.Players[2].HoleCards))
+
+	staff := gs.Redact(pokerface.ViewerObserver, pokerface.NewStaffRedactionPolicy())
+	for i, p := range staff.Players {
+		assert.Equal(t, gs.Players[i].HoleCards, p.HoleCards)
+	}
+
+	// Original state is not modified
+	for _, p := range gs.Players {
+		assert.Equal(t, 2, len(p.HoleCards))
+	}
+}
+
+func Test_Redact_ShownAndUpCards(t *testing.T) {
+
+	g := newRedactGame(t)
+
+	assert.Equal(t, pokerface.ErrInvalidCard, g.Player(1).Show(2))
+	assert.Nil(t, g.Player(1).Show(1))
+
+	gs := g.GetState()
+
+	observer := gs.Redact(pokerface.ViewerObserver, nil)
+	assert.Equal(t, 0, len(observer.Players[0].HoleCards))
+	assert.Equal(t, gs.Players[1].HoleCards[1:], observer.Players[1].HoleCards)
+
+	// The first card is face up
+	policy := pokerface.NewRedactionPolicy()
+	policy.UpCards = []int{0}
+
+	observer = gs.Redact(pokerface.ViewerObserver, policy)
+	assert.Equal(t, gs.Players[0].HoleCards[:1], observer.Players[0].HoleCards)
+	assert.Equal(t, gs.Players[1].HoleCards, observer.Players[1].HoleCards)
+	assert.Equal(t, gs.Players[2].HoleCards[:1], observer.Players[2].HoleCards)
+
+	// Showing all cards
+	assert.Nil(t, g.Player(2).Show())
+	assert.Equal(t, []int{0, 1}, g.GetState().Players[2].ShownCards)
+}
+
+func Test_Redact_AllinShowdown(t *testing.T) {
+
+	g := newRedactGame(t)
+
+	// Dealer and SB are all-in, BB folds
+	assert.Nil(t, g.Allin())
+	assert.Nil(t, g.Allin())
+	assert.Nil(t, g.Fold())
+
+	gs := g.GetState()
+	assert.NotEqual(t, "GameClosed", gs.Status.CurrentEvent)
+
+	observer := gs.Redact(pokerface.ViewerObserver, nil)
+	for _, p := range observer.Players {
+		assert.Equal(t, 0, len(p.HoleCards))
+	}
+
+	policy := pokerface.NewRedactionPolicy()
+	policy.ExposeAllIn = true
+
+	observer = gs.Redact(pokerface.ViewerObserver, policy)
+	assert.Equal(t, gs.Players[0].HoleCards, observer.Players[0].HoleCards)
+	assert.Equal(t, gs.Players[1].HoleCards, observer.Players[1].HoleCards)
+	assert.Equal(t, 0, len(observer.Players[2].HoleCards))
+}
+
+func Test_Redact_MuckLosers(t *testing.T) {
+
+	g := newRedactGame(t)
+	runCheckDown(t, g)
+
+	gs := g.GetState()
+
+	winners := make(map[int]bool)
+	for _, pr := range gs.Result.Pots {
+		for _, w := range pr.Winners {
+			winners[w.Idx] = true
+		}
+	}
+
+	// All of hands are revealed at showdown by default
+	observer := gs.Redact(pokerface.ViewerObserver, nil)
+	for i, p := range observer.Players {
+		assert.Equal(t, gs.Players[i].HoleCards, p.HoleCards)
+	}
+
+	policy := pokerface.NewRedactionPolicy()
+	policy.MuckLosers = true
+
+	observer = gs.Redact(pokerface.ViewerObserver, policy)
+	for i, p := range observer.Players {
+		if winners[i] {
+			assert.Equal(t, gs.Players[i].HoleCards, p.HoleCards)
+			assert.NotNil(t, p.Combination)
+		} else {
+			assert.Equal(t, 0, len(p.HoleCards))
+			assert.Nil(t, p.Combination)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package pokerface
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokerface"
+)
+
+func newTestRunner() *pokerface.Runner {
+	pf := pokerface.NewPokerFace()
+	return pokerface.NewRunner(pf.NewGame(newSecretsTestOptions()))
+}
+
+func TestRunner_ConcurrentPlayers(t *testing.T) {
+
+	r := newTestRunner()
+	defer r.Close()
+
+	states, cancel := r.Subscribe(1024)
+	defer cancel()
+
+	_, err := r.Do(pokerface.CmdStart{})
+	assert.Nil(t, err)
+
+	closed := func(gs *pokerface.GameState) bool {
+		return gs.Status.CurrentEvent == "GameClosed"
+	}
+
+	var wg sync.WaitGroup
+
+	// Dealer
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		for {
+			gs, err := r.GetState()
+			if !assert.Nil(t, err) || closed(gs) {
+				return
+			}
+
+			switch gs.Status.CurrentEvent {
+			case "ReadyRequested":
+				_, err = r.Do(pokerface.CmdReadyForAll{})
+			case "BlindsRequested":
+				_, err = r.Do(pokerface.CmdPayBlinds{})
+			case "RoundClosed":
+				_, err = r.Do(pokerface.CmdNext{})
+			default:
+				runtime.Gosched()
+			}
+
+			assert.Nil(t, err)
+		}
+	}()
+
+	// Every player acts on its own goroutine
+	for idx := 0; idx < 3; idx++ {
+		wg.Add(1)
+		go func(idx int) {
+			defer wg.Done()
+
+			for {
+				gs, err := r.GetState()
+				if !assert.Nil(t, err) || closed(gs) {
+					return
+				}
+
+				if gs.Status.CurrentEvent != "RoundStarted" || gs.Status.CurrentPlayer != idx {
+					runtime.Gosched()
+					continue
+				}
+
+				var cmd pokerface.Command = pokerface.CmdCall{Player: idx}
+				for _, action := range gs.GetPlayer(idx).AllowedActions {
+					if action == "check" {
+						cmd = pokerface.CmdCheck{Player: idx}
+					}
+				}
+
+				_, err = r.Do(cmd)
+				assert.Nil(t, err)
+			}
+		}(idx)
+	}
+
+	wg.Wait()
+
+	gs, err := r.GetState()
+	assert.Nil(t, err)
+	assert.Equal(t, "GameClosed", gs.Status.CurrentEvent)
+	assert.Equal(t, 0, len(pokerface.Audit(gs)))
+
+	// Every successful command was published
+	var last *pokerface.GameState
+	for len(states) > 0 {
+		last = <-states
+	}
+	assert.Equal(t, gs.Status.CurrentEvent, last.Status.CurrentEvent)
+	assert.Equal(t, gs.Result, last.Result)
+}
+
+func TestRunner_Commands(t *testing.T) {
+
+	r := newTestRunner()
+	defer r.Close()
+
+	for _, cmd := range []pokerface.Command{
+		pokerface.CmdStart{},
+		pokerface.CmdReadyForAll{},
+		pokerface.CmdPayBlinds{},
+		pokerface.CmdReadyForAll{},
+	} {
+		_, err := r.Do(cmd)
+		assert.Nil(t, err)
+	}
+
+	gs, err := r.GetState()
+	assert.Nil(t, err)
+	assert.Equal(t, "RoundStarted", gs.Status.CurrentEvent)
+	assert.Equal(t, 0, gs.Status.CurrentPlayer)
+
+	// Out of turn
+	_, err = r.Do(pokerface.CmdFold{Player: 1})
+	assert.Equal(t, pokerface.ErrNotCurrentPlayer, err)
+
+	_, err = r.Do(pokerface.CmdFold{Player: 5})
+	assert.Equal(t, pokerface.ErrUnknownPlayer, err)
+
+	// Returned state is a copy
+	gs.Players[0].StackSize = 0
+
+	f := r.Submit(pokerface.CmdRaise{Player: 0, ChipLevel: 30})
+	<-f.Done()
+	gs, err = f.Wait()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(30), gs.Players[0].Wager)
+	assert.Equal(t, int64(9970), gs.Players[0].StackSize)
+	assert.Equal(t, 1, gs.Status.CurrentPlayer)
+
+	// Function for operations which have no command
+	gs, err = r.Do(pokerface.CmdFunc(func(g pokerface.Game) error {
+		return g.GetCurrentPlayer().Fold()
+	}))
+	assert.Nil(t, err)
+	assert.True(t, gs.GetPlayer(1).Fold)
+
+	// Runner survives panic of command
+	_, err = r.Do(pokerface.CmdFunc(func(g pokerface.Game) error {
+		panic("boom")
+	}))
+	assert.True(t, errors.Is(err, pokerface.ErrCommandPanicked))
+
+	gs, err = r.Do(pokerface.CmdCall{Player: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, "RoundClosed", gs.Status.CurrentEvent)
+}
+
+func TestRunner_Close(t *testing.T) {
+
+	r := newTestRunner()
+
+	states, _ := r.Subscribe(1)
+
+	_, err := r.Do(pokerface.CmdStart{})
+	assert.Nil(t, err)
+
+	// Failed command is not published
+	_, err = r.Do(pokerface.CmdNext{})
+	assert.NotNil(t, err)
+
+	gs := <-states
+	assert.Equal(t, "ReadyRequested", gs.Status.CurrentEvent)
+
+	assert.Nil(t, r.Close())
+	assert.Nil(t, r.Close())
+
+	_, ok := <-states
+	assert.False(t, ok)
+
+	_, err = r.Do(pokerface.CmdReadyForAll{})
+	assert.Equal(t, pokerface.ErrRunnerClosed, err)
+
+	states, _ = r.Subscribe(1)
+	_, ok = <-states
+	assert.False(t, ok)
+}
@@ -0,0 +1,31 @@
+package pokerface
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokerface/scenario"
+	"github.com/weedbox/pokerface/table"
+)
+
+func TestScenario_Fixtures(t *testing.T) {
+
+	scenarios, err := scenario.Load("scenarios/*.scenario")
+	assert.Nil(t, err)
+	assert.NotEmpty(t, scenarios)
+
+	for _, s := range scenarios {
+
+		s := s
+		t.Run(s.Name, func(t *testing.T) {
+
+			if r := scenario.RunGame(s); !r.Passed() {
+				t.Errorf("game:\n%s", r.String())
+			}
+
+			if r := scenario.RunBackend(s, table.NewNativeBackend()); !r.Passed() {
+				t.Errorf("backend:\n%s", r.String())
+			}
+		})
+	}
+}
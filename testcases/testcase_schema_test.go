@@ -0,0 +1,118 @@
+package pokerface
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokerface"
+	"github.com/weedbox/pokerface/card"
+	"github.com/weedbox/pokerface/codec"
+	"github.com/weedbox/pokerface/combination"
+)
+
+var schemaTestEngine = pokerface.NewPokerFace()
+
+func newSchemaTestGame(t *testing.T) pokerface.Game {
+
+	pf := schemaTestEngine
+
+	opts := pokerface.NewStardardGameOptions()
+	opts.Deck = pokerface.NewStandardDeckCards()
+	opts.Players = append(opts.Players,
+		&pokerface.PlayerSetting{
+			Bankroll:  10000,
+			Positions: []string{"dealer", "sb"},
+		},
+		&pokerface.PlayerSetting{
+			Bankroll:  10000,
+			Positions: []string{"bb"},
+		},
+	)
+
+	g := pf.NewGame(opts)
+	assert.Nil(t, g.Start())
+	assert.Equal(t, pokerface.SchemaVersion, g.GetState().SchemaVersion)
+
+	return g
+}
+
+func TestSchema_MigrateLegacyDocument(t *testing.T) {
+
+	g := newSchemaTestGame(t)
+
+	// Document was persisted before versioning
+	data, _ := g.GetStateJSON()
+	var doc map[string]interface{}
+	assert.Nil(t, json.Unmarshal(data, &doc))
+	delete(doc, "schema_version")
+	delete(doc["meta"].(map[string]interface{}), "evaluator")
+	delete(doc["meta"].(map[string]interface{}), "locale")
+
+	// Deck was in state
+	doc["meta"].(map[string]interface{})["deck"] = card.Strings(g.GetSecrets().Deck)
+	data, _ = json.Marshal(doc)
+
+	gs, err := pokerface.UnmarshalStateJSON(data)
+	assert.Nil(t, err)
+	assert.Equal(t, pokerface.SchemaVersion, gs.SchemaVersion)
+	assert.Equal(t, combination.EvaluatorHigh, gs.Meta.Evaluator)
+	assert.Equal(t, combination.LocaleEnglish, gs.Meta.Locale)
+
+	// Game is able to continue
+	restored, err := pokerface.NewPokerFace().LoadGame(gs)
+	assert.Nil(t, err)
+	assert.Equal(t, "ReadyRequested", restored.GetState().Status.CurrentEvent)
+	assert.Nil(t, restored.ReadyForAll())
+
+	// State which was decoded without migration is upgraded by LoadState
+	var legacy pokerface.GameState
+	assert.Nil(t, json.Unmarshal(data, &legacy))
+	assert.Equal(t, 0, legacy.SchemaVersion)
+
+	_, err = pokerface.NewPokerFace().LoadGame(&legacy)
+	assert.Nil(t, err)
+	assert.Equal(t, pokerface.SchemaVersion, legacy.SchemaVersion)
+	assert.Equal(t, combination.EvaluatorHigh, legacy.Meta.Evaluator)
+}
+
+func TestSchema_RefuseNewerVersion(t *testing.T) {
+
+	g := newSchemaTestGame(t)
+
+	gs := g.GetState()
+	gs.SchemaVersion = pokerface.SchemaVersion + 1
+
+	_, err := pokerface.NewPokerFace().LoadGame(gs)
+	assert.True(t, errors.Is(err, pokerface.ErrUnsupportedSchemaVersion))
+	assert.Contains(t, err.Error(), "supported up to")
+
+	// Game is never created without state
+	_, err = pokerface.LoadGame(gs)
+	assert.True(t, errors.Is(err, pokerface.ErrUnsupportedSchemaVersion))
+	assert.Nil(t, pokerface.NewGameFromState(gs))
+
+	data, _ := json.Marshal(gs)
+	_, err = pokerface.UnmarshalStateJSON(data)
+	assert.True(t, errors.Is(err, pokerface.ErrUnsupportedSchemaVersion))
+}
+
+func TestSchema_BinaryFormatVersion1(t *testing.T) {
+
+	g := newSchemaTestGame(t)
+
+	// Format version 1 has no schema version
+	e := codec.NewEncoderVersion(1)
+	e.WriteHeader(codec.KindGameState)
+	pokerface.EncodeGameState(e, g.GetState())
+
+	gs, err := pokerface.UnmarshalGameState(e.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, 0, gs.SchemaVersion)
+	assert.Equal(t, g.GetState().GameID, gs.GameID)
+
+	_, err = schemaTestEngine.LoadGame(gs)
+	assert.Nil(t, err)
+	assert.Equal(t, pokerface.SchemaVersion, gs.SchemaVersion)
+}
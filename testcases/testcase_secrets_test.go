@@ -0,0 +1,176 @@
+package pokerface
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokerface"
+	"github.com/weedbox/pokerface/card"
+	"github.com/weedbox/pokerface/table"
+)
+
+func newSecretsTestOptions() *pokerface.GameOptions {
+
+	opts := pokerface.NewStardardGameOptions()
+	opts.Deck = pokerface.NewStandardDeckCards()
+	opts.Players = append(opts.Players,
+		&pokerface.PlayerSetting{
+			Bankroll:  10000,
+			Positions: []string{"dealer"},
+		},
+		&pokerface.PlayerSetting{
+			Bankroll:  10000,
+			Positions: []string{"sb"},
+		},
+		&pokerface.PlayerSetting{
+			Bankroll:  10000,
+			Positions: []string{"bb"},
+		},
+	)
+
+	return opts
+}
+
+func TestSecrets_NotInState(t *testing.T) {
+
+	store := pokerface.NewMemorySecretStore()
+	pf := pokerface.NewPokerFace(pokerface.WithSecretStore(store))
+
+	g := pf.NewGame(newSecretsTestOptions())
+	assert.Nil(t, g.Start())
+
+	// Game is rebuilt from a copy of state for every step like backends do
+	for g.GetState().Status.CurrentEvent != "GameClosed" {
+
+		gs := g.GetState()
+		assert.Equal(t, 0, len(gs.Meta.Deck))
+		assert.Equal(t, 0, len(gs.Status.Burned))
+
+		// Cards of state are never in the remaining deck
+		remaining := card.NewCardSet(g.GetSecrets().Deck...)
+		for _, c := range gs.Status.Board {
+			assert.False(t, remaining.Contains(c))
+		}
+
+		for _, p := range gs.Players {
+			for _, c := range p.HoleCards {
+				assert.False(t, remaining.Contains(c))
+			}
+		}
+
+		assert.Equal(t, 52, len(g.GetSecrets().Deck)+gs.Status.CurrentDeckPosition)
+
+		restored, err := pf.LoadGame(gs.Clone())
+		assert.Nil(t, err)
+		g = restored
+
+		switch g.GetState().Status.CurrentEvent {
+		case "ReadyRequested":
+			assert.Nil(t, g.ReadyForAll())
+		case "AnteRequested":
+			assert.Nil(t, g.PayAnte())
+		case "BlindsRequested":
+			assert.Nil(t, g.PayBlinds())
+		case "RoundClosed":
+			assert.Nil(t, g.Next())
+		case "RoundStarted":
+			if g.GetCurrentPlayer().CheckAction("check") {
+				assert.Nil(t, g.Check())
+			} else {
+				assert.Nil(t, g.Call())
+			}
+		}
+	}
+
+	gs := g.GetState()
+	assert.Equal(t, 5, len(gs.Status.Board))
+	assert.Equal(t, 3, len(g.GetSecrets().Burned))
+
+	// Secrets are deleted once game is closed
+	_, err := store.LoadSecrets(gs.GameID)
+	assert.Equal(t, pokerface.ErrSecretsNotFound, err)
+}
+
+func TestSecrets_Missing(t *testing.T) {
+
+	g := pokerface.NewPokerFace().NewGame(newSecretsTestOptions())
+	assert.Nil(t, g.Start())
+	assert.Nil(t, g.ReadyForAll())
+
+	// Secrets of the hand in progress are lost
+	_, err := pokerface.NewPokerFace().LoadGame(g.GetState().Clone())
+	assert.Equal(t, pokerface.ErrSecretsNotFound, err)
+	assert.Nil(t, pokerface.NewPokerFace().NewGameFromState(g.GetState().Clone()))
+
+	_, err = pokerface.LoadGame(g.GetState().Clone())
+	assert.Equal(t, pokerface.ErrSecretsNotFound, err)
+	assert.Nil(t, pokerface.NewGameFromState(g.GetState().Clone()))
+
+	_, err = table.NewNativeBackend().PayBlinds(g.GetState())
+	assert.Equal(t, pokerface.ErrSecretsNotFound, err)
+
+	// Closed game has no secrets
+	gs := g.GetState().Clone()
+	gs.Status.CurrentEvent = "GameClosed"
+	_, err = pokerface.NewPokerFace().LoadGame(gs)
+	assert.Nil(t, err)
+}
+
+func TestSecrets_LegacyState(t *testing.T) {
+
+	g := pokerface.NewPokerFace().NewGame(newSecretsTestOptions())
+	assert.Nil(t, g.Start())
+	assert.Nil(t, g.ReadyForAll())
+
+	// Legacy state has deck and burned cards
+	gs := g.GetState().Clone()
+	gs.Meta.Deck = append(make([]card.Card, gs.Status.CurrentDeckPosition), g.GetSecrets().Deck...)
+	gs.Status.Burned = card.MustParseCards("S2")
+
+	restored, err := pokerface.NewPokerFace().LoadGame(gs)
+	assert.Nil(t, err)
+	assert.Equal(t, g.GetSecrets().Deck, restored.GetSecrets().Deck)
+	assert.Equal(t, card.MustParseCards("S2"), restored.GetSecrets().Burned)
+	assert.Equal(t, 0, len(restored.GetState().Meta.Deck))
+	assert.Equal(t, 0, len(restored.GetState().Status.Burned))
+}
+
+func TestSecrets_Codec(t *testing.T) {
+
+	g := pokerface.NewPokerFace().NewGame(newSecretsTestOptions())
+	assert.Nil(t, g.Start())
+
+	data, err := pokerface.MarshalSecrets(g.GetSecrets())
+	assert.Nil(t, err)
+
+	s, err := pokerface.UnmarshalSecrets(data)
+	assert.Nil(t, err)
+	assert.Equal(t, g.GetSecrets(), s)
+
+	_, err = pokerface.UnmarshalSecrets(data[:len(data)-1])
+	assert.NotNil(t, err)
+
+	// Secrets are restored into another game
+	restored, err := pokerface.LoadGameWithSecrets(g.GetState().Clone(), s)
+	assert.Nil(t, err)
+	assert.Equal(t, s, restored.GetSecrets())
+}
+
+// brokenSecretStore is unable to save secrets
+type brokenSecretStore struct {
+	pokerface.SecretStore
+}
+
+func (s *brokenSecretStore) SaveSecrets(gameID string, secrets *pokerface.Secrets) error {
+	return errors.New("store: disk full")
+}
+
+func TestSecrets_SaveError(t *testing.T) {
+
+	store := &brokenSecretStore{pokerface.NewMemorySecretStore()}
+	g := pokerface.NewPokerFace(pokerface.WithSecretStore(store)).NewGame(newSecretsTestOptions())
+
+	// Deck is never lost silently
+	assert.EqualError(t, g.Start(), "store: disk full")
+}
@@ -0,0 +1,58 @@
+package pokerface
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weedbox/pokerface"
+	"github.com/weedbox/pokerface/card"
+	"github.com/weedbox/pokerface/combination"
+)
+
+func TestWild_JokersAndDeuces(t *testing.T) {
+
+	e := combination.NewWildEvaluator(combination.NewHighEvaluator(combination.CombinationPowerWild), []int{2})
+
+	for i := 0; i < 20; i++ {
+
+		pf := pokerface.NewPokerFace()
+
+		opts := pokerface.NewStardardGameOptions()
+		opts.CombinationPowers = combination.CombinationPowerWild
+		opts.WildRanks = []int{2}
+		opts.Deck = pokerface.NewJokerDeckCards(2)
+		opts.Players = append(opts.Players,
+			&pokerface.PlayerSetting{
+				Bankroll:  10000,
+				Positions: []string{"dealer"},
+			},
+			&pokerface.PlayerSetting{
+				Bankroll:  10000,
+				Positions: []string{"sb"},
+			},
+			&pokerface.PlayerSetting{
+				Bankroll:  10000,
+				Positions: []string{"bb"},
+			},
+		)
+
+		g := pf.NewGame(opts)
+		assert.Nil(t, g.Start())
+		assert.Equal(t, 54, len(g.GetSecrets().Deck))
+
+		runCheckDown(t, g)
+
+		gs := g.GetState()
+		for _, p := range gs.Players {
+
+			cards := make([]card.Card, 0)
+			cards = append(cards, p.HoleCards...)
+			cards = append(cards, gs.Status.Board...)
+
+			assert.Equal(t, int(e.Evaluate(cards)), p.Combination.Power)
+
+			// Combination has original cards rather than substitutions
+			assert.Subset(t, cards, p.Combination.Cards)
+		}
+	}
+}